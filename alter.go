@@ -0,0 +1,871 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* ALTER TABLE statement and its application to a Table */
+
+// AlterTableStatement is ALTER TABLE [IF EXISTS] [ONLY] name spec [, spec ...].
+// ONLY is PostgreSQL's and has no effect on the schema.
+type AlterTableStatement struct {
+	Table    string
+	IfExists bool
+	Specs    []*AlterSpec
+}
+
+// AlterSpec is one alteration of ALTER TABLE. Action tells which fields are set:
+//
+//	"ADD COLUMN"       Column, First, After
+//	"MODIFY COLUMN"    Column, First, After
+//	"CHANGE COLUMN"    Name (the old name), Column, First, After
+//	"RENAME COLUMN"    Name, NewName
+//	"DROP COLUMN"      Name
+//	"SET DEFAULT"      Name, Default
+//	"DROP DEFAULT"     Name
+//	"SET NOT NULL"     Name
+//	"DROP NOT NULL"    Name
+//	"SET TYPE"         Name, Column holding the new type
+//	"ADD IDENTITY"     Name, Value ("ALWAYS" or "BY DEFAULT")
+//	"DROP IDENTITY"    Name
+//	"ADD INDEX"        Index, including primary, unique, fulltext and spatial keys
+//	"DROP INDEX"       Name, "PRIMARY" for DROP PRIMARY KEY
+//	"RENAME INDEX"     Name, NewName
+//	"ADD FOREIGN KEY"  ForeignKey
+//	"DROP FOREIGN KEY" Name
+//	"ADD CHECK"        Check
+//	"DROP CHECK"       Name
+//	"DROP CONSTRAINT"  Name of a foreign key, check or unique key
+//	"RENAME TABLE"     NewName
+//	"OPTION"           Name, Value of a table option such as engine or ALGORITHM;
+//	                   OWNER TO role is the option owner
+//	"CONVERT"          Value (the character set), Collation
+//	"DISABLE KEYS", "ENABLE KEYS"
+type AlterSpec struct {
+	Action     string
+	Name       string
+	NewName    string
+	Value      string
+	Collation  string
+	Column     *Column
+	First      bool
+	After      string
+	Default    Expr
+	Index      *Index
+	ForeignKey *Constraint
+	Check      *CheckConstraint
+}
+
+func (*AlterTableStatement) stmt() {}
+
+// parseAlter dispatches on the kind of object following ALTER.
+func (p *Parser) parseAlter() (Statement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != ALTER {
+		return nil, fmt.Errorf("found %q, expected ALTER", litr)
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != TABLE {
+		return nil, &unknownObjectError{fmt.Sprintf("found ALTER %q, expected ALTER TABLE", litr)}
+	}
+	return notNil(p.parseAlterTable())
+}
+
+// parseAlterTable parses the table name and alterations following ALTER TABLE.
+func (p *Parser) parseAlterTable() (*AlterTableStatement, error) {
+	ifExists, err := p.scanIfExists()
+	if err != nil {
+		return nil, err
+	}
+	p.scanOptional(ONLY)
+	name, err := p.scanTableName()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &AlterTableStatement{Table: name, IfExists: ifExists}
+
+	for {
+		specs, err := p.scanAlterSpec(name)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Specs = append(stmt.Specs, specs...)
+
+		if !p.scanOptional(COMMA) {
+			return stmt, nil
+		}
+	}
+}
+
+// scanAlterSpec parses one alteration. A column definition with inline keys
+// yields a spec for the column followed by one per key.
+func (p *Parser) scanAlterSpec(table string) ([]*AlterSpec, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+
+	switch {
+	case isWord(tok, litr, "ADD"):
+		return p.scanAlterAdd(table)
+
+	case tok == DROP:
+		spec, err := p.scanAlterDrop()
+		return []*AlterSpec{spec}, err
+
+	case isWord(tok, litr, "MODIFY"), isWord(tok, litr, "CHANGE"):
+		spec := &AlterSpec{Action: strings.ToUpper(litr) + " COLUMN"}
+		p.scanColumnWord()
+		if spec.Action == "CHANGE COLUMN" {
+			tok, litr := p.scanIdent()
+			if tok != IDENT {
+				return nil, fmt.Errorf("found %q, expected column name", litr)
+			}
+			spec.Name = litr
+		}
+		return p.scanAlterColumn(table, spec)
+
+	case tok == ALTER:
+		spec, err := p.scanAlterColumnAction()
+		return []*AlterSpec{spec}, err
+
+	case isWord(tok, litr, "OWNER"):
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != TO {
+			return nil, fmt.Errorf("found %q, expected OWNER TO", litr)
+		}
+		tok, litr := p.scanIdent()
+		if tok != IDENT {
+			return nil, fmt.Errorf("found %q, expected role name", litr)
+		}
+		return []*AlterSpec{{Action: "OPTION", Name: "owner", Value: litr}}, nil
+
+	case tok == RENAME:
+		spec, err := p.scanAlterRename()
+		return []*AlterSpec{spec}, err
+
+	case isWord(tok, litr, "CONVERT"):
+		spec, err := p.scanAlterConvert()
+		return []*AlterSpec{spec}, err
+
+	case isWord(tok, litr, "DISABLE"), isWord(tok, litr, "ENABLE"):
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != KEY && !isWord(tok, litr, "KEYS") {
+			return nil, fmt.Errorf("found %q, expected KEYS", litr)
+		}
+		return []*AlterSpec{{Action: strings.ToUpper(litr) + " KEYS"}}, nil
+
+	case tok == SEMI_COLON || tok == EOF:
+		return nil, fmt.Errorf("found %q, expected alter specification", litr)
+	}
+
+	// anything else is a table option such as ENGINE=InnoDB or ALGORITHM=INPLACE
+	p.unScan()
+	name, value, err := p.scanTableOption()
+	if err != nil {
+		return nil, err
+	}
+	return []*AlterSpec{{Action: "OPTION", Name: name, Value: value}}, nil
+}
+
+// scanColumnWord consumes an optional COLUMN.
+func (p *Parser) scanColumnWord() {
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "COLUMN") {
+		p.unScan()
+	}
+}
+
+// scanAlterAdd parses the column, index or constraint following ADD.
+func (p *Parser) scanAlterAdd(table string) ([]*AlterSpec, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+
+	switch tok {
+	case PRIMARY, UNIQUE, KEY, INDEX, FULLTEXT, SPATIAL:
+		index, err := p.scanTableIndex(tok, litr)
+		if err != nil {
+			return nil, err
+		}
+		return []*AlterSpec{{Action: "ADD INDEX", Index: index}}, nil
+
+	case FOREIGN:
+		constraint, err := p.scanForeignKey()
+		if err != nil {
+			return nil, err
+		}
+		return []*AlterSpec{{Action: "ADD FOREIGN KEY", ForeignKey: constraint}}, nil
+
+	case CHECK:
+		check, err := p.scanCheck()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.scanNotEnforced(check); err != nil {
+			return nil, err
+		}
+		return []*AlterSpec{{Action: "ADD CHECK", Check: check}}, nil
+
+	case CONSTRAINT:
+		p.unScan()
+		definitions := newTable(table)
+		if err := p.scanConstraint(definitions); err != nil {
+			return nil, err
+		}
+		return definitionSpecs(definitions), nil
+
+	case OPEN_PARENTH:
+		// ADD (column, ...)
+		var specs []*AlterSpec
+		for {
+			column, err := p.scanAlterColumn(table, &AlterSpec{Action: "ADD COLUMN"})
+			if err != nil {
+				return nil, err
+			}
+			specs = append(specs, column...)
+
+			switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+			case COMMA:
+				continue
+			case CLOSE_PARENTH:
+				return specs, nil
+			default:
+				return nil, fmt.Errorf("found %q, expected , or )", litr)
+			}
+		}
+	}
+
+	p.unScan()
+	p.scanColumnWord()
+	return p.scanAlterColumn(table, &AlterSpec{Action: "ADD COLUMN"})
+}
+
+// scanAlterColumn parses a column definition and its FIRST or AFTER position
+// into spec.
+func (p *Parser) scanAlterColumn(table string, spec *AlterSpec) ([]*AlterSpec, error) {
+	definitions := newTable(table)
+	column, err := p.scanColumn(definitions)
+	if err != nil {
+		return nil, err
+	}
+	spec.Column = column
+	if spec.Name == "" {
+		spec.Name = column.Name
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); isWord(tok, litr, "FIRST") {
+		spec.First = true
+	} else if isWord(tok, litr, "AFTER") {
+		tok, litr := p.scanIdent()
+		if tok != IDENT {
+			return nil, fmt.Errorf("found %q, expected column name", litr)
+		}
+		spec.After = litr
+	} else {
+		p.unScan()
+	}
+
+	return append([]*AlterSpec{spec}, definitionSpecs(definitions)...), nil
+}
+
+// definitionSpecs turns the keys and constraints parsed into a scratch table
+// into ADD specs. Generated foreign key and check names are cleared so that
+// they are named after the altered table when applied.
+func definitionSpecs(definitions *Table) []*AlterSpec {
+	var specs []*AlterSpec
+	for _, index := range definitions.IndexList {
+		specs = append(specs, &AlterSpec{Action: "ADD INDEX", Index: index})
+	}
+	for _, constraint := range definitions.ConstraintList {
+		if strings.HasPrefix(constraint.Index, definitions.Name+"_ibfk_") {
+			constraint.Index = ""
+		}
+		specs = append(specs, &AlterSpec{Action: "ADD FOREIGN KEY", ForeignKey: constraint})
+	}
+	for _, check := range definitions.CheckList {
+		if strings.HasPrefix(check.Name, definitions.Name+"_chk_") {
+			check.Name = ""
+		}
+		specs = append(specs, &AlterSpec{Action: "ADD CHECK", Check: check})
+	}
+	return specs
+}
+
+// scanAlterDrop parses what follows DROP.
+func (p *Parser) scanAlterDrop() (*AlterSpec, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+
+	spec := &AlterSpec{}
+	switch {
+	case tok == PRIMARY:
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != KEY {
+			return nil, fmt.Errorf("found %q, expected PRIMARY KEY", litr)
+		}
+		return &AlterSpec{Action: "DROP INDEX", Name: "PRIMARY"}, nil
+	case tok == INDEX || tok == KEY:
+		spec.Action = "DROP INDEX"
+	case tok == FOREIGN:
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != KEY {
+			return nil, fmt.Errorf("found %q, expected FOREIGN KEY", litr)
+		}
+		spec.Action = "DROP FOREIGN KEY"
+	case tok == CHECK:
+		spec.Action = "DROP CHECK"
+	case tok == CONSTRAINT:
+		spec.Action = "DROP CONSTRAINT"
+	default:
+		p.unScan()
+		p.scanColumnWord()
+		spec.Action = "DROP COLUMN"
+	}
+
+	tok, litr = p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected name", litr)
+	}
+	spec.Name = litr
+	return spec, nil
+}
+
+// scanAlterColumnAction parses [COLUMN] name {SET DEFAULT value | DROP DEFAULT}
+// following ALTER, or one of the PostgreSQL actions {SET|DROP} NOT NULL,
+// [SET DATA] TYPE type [USING expr], ADD GENERATED ... AS IDENTITY and
+// DROP IDENTITY [IF EXISTS].
+func (p *Parser) scanAlterColumnAction() (*AlterSpec, error) {
+	p.scanColumnWord()
+
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected column name", litr)
+	}
+	spec := &AlterSpec{Name: litr}
+
+	tok, litr = p.scanIgnoreWhiteSpace()
+	switch {
+	case tok == SET || tok == DROP:
+		action := strings.ToUpper(litr)
+		tok1, litr1 := p.scanIgnoreWhiteSpace()
+		switch {
+		case tok1 == DEFAULT && tok == SET:
+			p.unScan()
+			val, err := p.scanDefault()
+			if err != nil {
+				return nil, err
+			}
+			spec.Action = "SET DEFAULT"
+			spec.Default = val
+		case tok1 == DEFAULT:
+			spec.Action = "DROP DEFAULT"
+		case tok1 == NOT:
+			if tok, litr := p.scanIgnoreWhiteSpace(); tok != NULL {
+				return nil, fmt.Errorf("found %q, expected %s NOT NULL", litr, action)
+			}
+			spec.Action = action + " NOT NULL"
+		case isWord(tok1, litr1, "IDENTITY") && tok == DROP:
+			if _, err := p.scanIfExists(); err != nil {
+				return nil, err
+			}
+			spec.Action = "DROP IDENTITY"
+		case isWord(tok1, litr1, "DATA") && tok == SET:
+			if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "TYPE") {
+				return nil, fmt.Errorf("found %q, expected SET DATA TYPE", litr)
+			}
+			return p.scanAlterType(spec)
+		case isWord(tok1, litr1, "TYPE") && tok == SET:
+			return p.scanAlterType(spec)
+		case tok == SET:
+			return nil, fmt.Errorf("found %q, expected SET DEFAULT", litr1)
+		default:
+			return nil, fmt.Errorf("found %q, expected DROP DEFAULT", litr1)
+		}
+
+	case isWord(tok, litr, "TYPE"):
+		return p.scanAlterType(spec)
+
+	case isWord(tok, litr, "ADD"):
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if !isWord(tok, litr, "GENERATED") {
+			return nil, fmt.Errorf("found %q, expected ADD GENERATED", litr)
+		}
+		column := &Column{}
+		if err := p.scanGenerated(tok, litr, column); err != nil {
+			return nil, err
+		}
+		if column.Identity == "" {
+			return nil, fmt.Errorf("found %q, expected IDENTITY", "("+column.Generated.String()+")")
+		}
+		spec.Action = "ADD IDENTITY"
+		spec.Value = column.Identity
+
+	default:
+		return nil, fmt.Errorf("found %q, expected SET DEFAULT or DROP DEFAULT", litr)
+	}
+	return spec, nil
+}
+
+// scanAlterType parses the type following [SET DATA] TYPE and its optional
+// USING conversion, which is not kept.
+func (p *Parser) scanAlterType(spec *AlterSpec) (*AlterSpec, error) {
+	spec.Action = "SET TYPE"
+	spec.Column = &Column{Name: spec.Name}
+	if err := p.scanType(spec.Column); err != nil {
+		return nil, err
+	}
+	if p.scanOptional(COLLATE) {
+		collation, err := p.scanCollation()
+		if err != nil {
+			return nil, err
+		}
+		spec.Column.Collation = collation
+	}
+	if p.scanOptional(USING) {
+		if _, err := p.ParseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
+
+// scanAlterRename parses what follows RENAME: [TO|AS] new_table,
+// COLUMN old TO new or {INDEX|KEY} old TO new.
+func (p *Parser) scanAlterRename() (*AlterSpec, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+
+	spec := &AlterSpec{}
+	switch {
+	case isWord(tok, litr, "COLUMN"):
+		spec.Action = "RENAME COLUMN"
+	case tok == INDEX || tok == KEY:
+		spec.Action = "RENAME INDEX"
+	default:
+		if tok != TO && !isWord(tok, litr, "AS") {
+			p.unScan()
+		}
+		name, err := p.scanTableName()
+		if err != nil {
+			return nil, err
+		}
+		return &AlterSpec{Action: "RENAME TABLE", NewName: name}, nil
+	}
+
+	tok, litr = p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected name", litr)
+	}
+	spec.Name = litr
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != TO {
+		return nil, fmt.Errorf("found %q, expected TO", litr)
+	}
+
+	tok, litr = p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected name", litr)
+	}
+	spec.NewName = litr
+	return spec, nil
+}
+
+// scanAlterConvert parses TO CHARACTER SET charset [COLLATE collation]
+// following CONVERT.
+func (p *Parser) scanAlterConvert() (*AlterSpec, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != TO {
+		return nil, fmt.Errorf("found %q, expected CONVERT TO", litr)
+	}
+
+	column := &Column{}
+	if err := p.scanColumnCharset(column); err != nil {
+		return nil, err
+	}
+	spec := &AlterSpec{Action: "CONVERT", Value: column.Charset}
+
+	if p.scanOptional(COLLATE) {
+		collation, err := p.scanCollation()
+		if err != nil {
+			return nil, err
+		}
+		spec.Collation = collation
+	}
+	return spec, nil
+}
+
+// Alter applies the alterations of an ALTER TABLE statement to the table in
+// order, stopping at the first one that cannot be applied, which leaves the
+// earlier ones applied. Renaming the table changes its Name, and its Database
+// when the new name is qualified. Schema.Apply alters a copy of the table, so
+// that a failing ALTER changes nothing, and moves it to its new name.
+func (table *Table) Alter(specs ...*AlterSpec) error {
+	for _, spec := range specs {
+		if err := table.alter(spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (table *Table) alter(spec *AlterSpec) error {
+	switch spec.Action {
+	case "ADD COLUMN":
+		if table.Columns[spec.Column.Name] != nil {
+			return fmt.Errorf("cannot add column %q, it already exists", spec.Column.Name)
+		}
+		table.addColumn(spec.Column)
+		table.resolveColumnCharset(spec.Column)
+		return table.placeColumn(spec.Column, spec.First, spec.After)
+
+	case "MODIFY COLUMN", "CHANGE COLUMN":
+		old := table.Columns[spec.Name]
+		if old == nil {
+			return fmt.Errorf("cannot change column %q, it does not exist", spec.Name)
+		}
+		if spec.Column.Name != spec.Name {
+			if err := table.renameColumn(spec.Name, spec.Column.Name); err != nil {
+				return err
+			}
+		}
+		table.addColumn(spec.Column)
+		table.resolveColumnCharset(spec.Column)
+		if spec.First || spec.After != "" {
+			return table.placeColumn(spec.Column, spec.First, spec.After)
+		}
+
+	case "RENAME COLUMN":
+		return table.renameColumn(spec.Name, spec.NewName)
+
+	case "DROP COLUMN":
+		return table.dropColumn(spec.Name)
+
+	case "SET DEFAULT", "DROP DEFAULT":
+		column := table.Columns[spec.Name]
+		if column == nil {
+			return fmt.Errorf("cannot alter column %q, it does not exist", spec.Name)
+		}
+		if isNextval(column.Default) {
+			column.AutoIncr = false
+		}
+		column.Default = nil
+		if spec.Default != nil {
+			column.Default = spec.Default
+			column.AutoIncr = column.AutoIncr || isNextval(spec.Default)
+		}
+
+	case "SET NOT NULL", "DROP NOT NULL", "SET TYPE", "ADD IDENTITY", "DROP IDENTITY":
+		column := table.Columns[spec.Name]
+		if column == nil {
+			return fmt.Errorf("cannot alter column %q, it does not exist", spec.Name)
+		}
+		switch spec.Action {
+		case "SET NOT NULL", "DROP NOT NULL":
+			column.Nullable = spec.Action == "DROP NOT NULL"
+		case "SET TYPE":
+			column.setType(spec.Column)
+		case "ADD IDENTITY":
+			column.Identity, column.AutoIncr = spec.Value, true
+		case "DROP IDENTITY":
+			column.Identity, column.AutoIncr = "", false
+		}
+
+	case "ADD INDEX":
+		index := spec.Index
+		if index.Kind == "PRIMARY" && table.PrimaryKey != nil {
+			return fmt.Errorf("cannot add a primary key to %q, it already has one", table.Name)
+		}
+		if index.Name != "" && (table.UniqueKeys[index.Name] != nil || table.Keys[index.Name] != nil) {
+			return fmt.Errorf("cannot add index %q, it already exists", index.Name)
+		}
+		table.addIndex(index)
+
+	case "DROP INDEX":
+		return table.dropIndex(spec.Name)
+
+	case "RENAME INDEX":
+		return table.renameIndex(spec.Name, spec.NewName)
+
+	case "ADD FOREIGN KEY":
+		if name := spec.ForeignKey.Index; name != "" && table.Constraints[name] != nil {
+			return fmt.Errorf("cannot add foreign key %q, it already exists", name)
+		}
+		table.addConstraint(spec.ForeignKey)
+
+	case "DROP FOREIGN KEY":
+		if table.Constraints[spec.Name] == nil {
+			return fmt.Errorf("cannot drop foreign key %q, it does not exist", spec.Name)
+		}
+		table.dropConstraint(spec.Name)
+
+	case "ADD CHECK":
+		if name := spec.Check.Name; name != "" && table.Checks[name] != nil {
+			return fmt.Errorf("cannot add check %q, it already exists", name)
+		}
+		table.addCheck(spec.Check)
+
+	case "DROP CHECK":
+		if table.Checks[spec.Name] == nil {
+			return fmt.Errorf("cannot drop check %q, it does not exist", spec.Name)
+		}
+		table.dropCheck(spec.Name)
+
+	case "DROP CONSTRAINT":
+		switch {
+		case table.Constraints[spec.Name] != nil:
+			table.dropConstraint(spec.Name)
+		case table.Checks[spec.Name] != nil:
+			table.dropCheck(spec.Name)
+		case table.UniqueKeys[spec.Name] != nil,
+			table.PrimaryKey != nil && table.PrimaryKey.Name == spec.Name:
+			return table.dropIndex(spec.Name)
+		default:
+			return fmt.Errorf("cannot drop constraint %q, it does not exist", spec.Name)
+		}
+
+	case "RENAME TABLE":
+		table.rename(ParseQualifiedName(spec.NewName))
+		for _, trigger := range table.Triggers {
+			trigger.Table = spec.NewName
+		}
+
+	case "OPTION":
+		// ALGORITHM and LOCK only tell how to run the ALTER
+		if spec.Name != "algorithm" && spec.Name != "lock" {
+			return table.setOption(spec.Name, spec.Value)
+		}
+
+	case "CONVERT":
+		table.setOption("charset", spec.Value)
+		delete(table.Extras, "collate")
+		table.Collation = ""
+		if spec.Collation != "" {
+			table.setOption("collate", spec.Collation)
+		}
+		for _, column := range table.ColumnList {
+			if isCharacterType(column.Type) {
+				column.Charset, column.Collation = table.Charset, table.Collation
+			}
+		}
+	}
+
+	return nil
+}
+
+// setType replaces the type of the column with the type of from.
+func (column *Column) setType(from *Column) {
+	column.Type, column.TypeName = from.Type, from.TypeName
+	column.Size, column.Fsp = from.Size, from.Fsp
+	column.Precision, column.Scale = from.Precision, from.Scale
+	column.Members = from.Members
+	column.TimeZone, column.Dimensions = from.TimeZone, from.Dimensions
+	if from.Collation != "" {
+		column.Collation = from.Collation
+	}
+}
+
+// resolveColumnCharset resolves the character set of one added or changed column.
+func (table *Table) resolveColumnCharset(column *Column) {
+	columns := table.ColumnList
+	table.ColumnList = []*Column{column}
+	table.resolveCharsets()
+	table.ColumnList = columns
+}
+
+// placeColumn moves a column of the table first or after another column.
+func (table *Table) placeColumn(column *Column, first bool, after string) error {
+	if !first && after == "" {
+		return nil
+	}
+	if !first && table.Columns[after] == nil {
+		return fmt.Errorf("cannot place column %q after %q, it does not exist", column.Name, after)
+	}
+
+	var columns []*Column
+	if first {
+		columns = append(columns, column)
+	}
+	for _, c := range table.ColumnList {
+		if c == column {
+			continue
+		}
+		columns = append(columns, c)
+		if c.Name == after {
+			columns = append(columns, column)
+		}
+	}
+	table.ColumnList = columns
+	return nil
+}
+
+// renameColumn renames a column along with its uses by indexes and foreign
+// keys; like MySQL it fails when a check or generated column uses it.
+func (table *Table) renameColumn(from, to string) error {
+	column := table.Columns[from]
+	if column == nil {
+		return fmt.Errorf("cannot rename column %q, it does not exist", from)
+	}
+	if table.Columns[to] != nil {
+		return fmt.Errorf("cannot rename column %q to %q, it already exists", from, to)
+	}
+	if user := table.expressionUser(from); user != "" {
+		return fmt.Errorf("cannot rename column %q, %s uses it", from, user)
+	}
+
+	delete(table.Columns, from)
+	column.Name = to
+	table.Columns[to] = column
+
+	for _, index := range table.IndexList {
+		for _, c := range index.Columns {
+			if c.Name == from {
+				c.Name = to
+			}
+		}
+	}
+	for _, constraint := range table.ConstraintList {
+		for i, c := range constraint.Columns {
+			if c == from {
+				constraint.Columns[i] = to
+			}
+		}
+		if constraint.ForeignKey == from {
+			constraint.ForeignKey = to
+		}
+	}
+	return nil
+}
+
+// dropColumn removes a column, removing it from indexes too; indexes left
+// without columns are dropped. Like MySQL it fails when a foreign key, check
+// or generated column uses the column.
+func (table *Table) dropColumn(name string) error {
+	column := table.Columns[name]
+	if column == nil {
+		return fmt.Errorf("cannot drop column %q, it does not exist", name)
+	}
+	for _, constraint := range table.ConstraintList {
+		for _, c := range constraint.Columns {
+			if c == name {
+				return fmt.Errorf("cannot drop column %q, foreign key %q uses it", name, constraint.Index)
+			}
+		}
+	}
+	if user := table.expressionUser(name); user != "" {
+		return fmt.Errorf("cannot drop column %q, %s uses it", name, user)
+	}
+
+	delete(table.Columns, name)
+	var columns []*Column
+	for _, c := range table.ColumnList {
+		if c != column {
+			columns = append(columns, c)
+		}
+	}
+	table.ColumnList = columns
+
+	for _, index := range append([]*Index(nil), table.IndexList...) {
+		var kept []*IndexColumn
+		for _, c := range index.Columns {
+			if c.Name != name {
+				kept = append(kept, c)
+			}
+		}
+		index.Columns = kept
+		if len(kept) == 0 {
+			table.dropIndex(index.Name)
+		}
+	}
+	return nil
+}
+
+// expressionUser names the first check or generated column whose expression
+// uses the column name, "" for none.
+func (table *Table) expressionUser(name string) string {
+	for _, check := range table.CheckList {
+		if usesColumn(check.Expr, name) {
+			return fmt.Sprintf("check %q", check.Name)
+		}
+	}
+	for _, column := range table.ColumnList {
+		if column.Name != name && usesColumn(column.Generated, name) {
+			return fmt.Sprintf("generated column %q", column.Name)
+		}
+	}
+	return ""
+}
+
+// usesColumn reports whether the expression x names the column name.
+func usesColumn(x Expr, name string) bool {
+	used := false
+	Inspect(x, func(e Expr) bool {
+		if ident, ok := e.(*Ident); ok && strings.EqualFold(ident.Name, name) {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
+
+// dropIndex removes an index; PRIMARY, or its constraint name, names the
+// primary key.
+func (table *Table) dropIndex(name string) error {
+	var index *Index
+	switch {
+	case name == "PRIMARY", table.PrimaryKey != nil && table.PrimaryKey.Name == name:
+		index = table.PrimaryKey
+		table.PrimaryKey = nil
+	case table.UniqueKeys[name] != nil:
+		index = table.UniqueKeys[name]
+		delete(table.UniqueKeys, name)
+	case table.Keys[name] != nil:
+		index = table.Keys[name]
+		delete(table.Keys, name)
+	}
+	if index == nil {
+		return fmt.Errorf("cannot drop index %q, it does not exist", name)
+	}
+
+	var indexes []*Index
+	for _, i := range table.IndexList {
+		if i != index {
+			indexes = append(indexes, i)
+		}
+	}
+	table.IndexList = indexes
+	return nil
+}
+
+// renameIndex renames a unique or plain index.
+func (table *Table) renameIndex(from, to string) error {
+	keys := table.Keys
+	if table.UniqueKeys[from] != nil {
+		keys = table.UniqueKeys
+	}
+	index := keys[from]
+	if index == nil {
+		return fmt.Errorf("cannot rename index %q, it does not exist", from)
+	}
+	if to == "PRIMARY" || table.UniqueKeys[to] != nil || table.Keys[to] != nil {
+		return fmt.Errorf("cannot rename index %q to %q, it already exists", from, to)
+	}
+
+	delete(keys, from)
+	index.Name = to
+	keys[to] = index
+	return nil
+}
+
+func (table *Table) dropConstraint(name string) {
+	constraint := table.Constraints[name]
+	delete(table.Constraints, name)
+
+	var constraints []*Constraint
+	for _, c := range table.ConstraintList {
+		if c != constraint {
+			constraints = append(constraints, c)
+		}
+	}
+	table.ConstraintList = constraints
+}
+
+func (table *Table) dropCheck(name string) {
+	check := table.Checks[name]
+	delete(table.Checks, name)
+
+	var checks []*CheckConstraint
+	for _, c := range table.CheckList {
+		if c != check {
+			checks = append(checks, c)
+		}
+	}
+	table.CheckList = checks
+}
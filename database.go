@@ -0,0 +1,269 @@
+package SQLParser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/* Qualified names, CREATE DATABASE and DROP DATABASE statements */
+
+// QualifiedName is a name qualified by a schema and a catalog, e.g.
+// shop.orders or catalog.shop.orders. MySQL calls the schema a database.
+//
+// Statements hold table names as the String form of their QualifiedName and
+// ParseQualifiedName splits such a name back into its parts. A Table keeps
+// the parts of its name apart, so the tables of different databases coexist
+// in a Schema.
+type QualifiedName struct {
+	Catalog string
+	Schema  string
+	Name    string
+}
+
+// String joins the parts of the name with dots. Parts containing a dot or
+// a backquote are quoted with backquotes.
+func (n QualifiedName) String() string {
+	var parts []string
+	for _, part := range []string{n.Catalog, n.Schema, n.Name} {
+		if part == "" && parts == nil {
+			continue
+		}
+		if strings.ContainsAny(part, ".`") {
+			part = "`" + strings.Replace(part, "`", "``", -1) + "`"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// ParseQualifiedName splits a name as formatted by QualifiedName.String.
+func ParseQualifiedName(s string) QualifiedName {
+	var parts []string
+	var part strings.Builder
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '`' && quoted && i+1 < len(s) && s[i+1] == '`':
+			part.WriteByte('`')
+			i++
+		case c == '`':
+			quoted = !quoted
+		case c == '.' && !quoted:
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(c)
+		}
+	}
+	parts = append(parts, part.String())
+
+	var n QualifiedName
+	switch len(parts) {
+	case 1:
+		n.Name = parts[0]
+	case 2:
+		n.Schema, n.Name = parts[0], parts[1]
+	default:
+		n.Catalog, n.Schema, n.Name = strings.Join(parts[:len(parts)-2], "."), parts[len(parts)-2], parts[len(parts)-1]
+	}
+	return n
+}
+
+// QualifiedName returns the name of the table qualified by its database.
+func (table *Table) QualifiedName() QualifiedName {
+	return QualifiedName{Catalog: table.Catalog, Schema: table.Database, Name: table.Name}
+}
+
+// rename gives the table the name n, keeping its database when n has none.
+func (table *Table) rename(n QualifiedName) {
+	if n.Schema != "" {
+		table.Catalog, table.Database = n.Catalog, n.Schema
+	}
+	table.Name = n.Name
+}
+
+// relative returns the name n as written after USE database, without the
+// database when it is the one in use. Schema keys tables by such names.
+func relative(n QualifiedName, database string) string {
+	if n.Catalog == "" && n.Schema == database {
+		n.Schema = ""
+	}
+	return n.String()
+}
+
+// absolute returns the name of the table named name after USE database.
+func absolute(name, database string) QualifiedName {
+	n := ParseQualifiedName(name)
+	if n.Schema == "" {
+		n.Schema = database
+	}
+	return n
+}
+
+// scanQualifiedName parses name, schema.name or catalog.schema.name; what
+// names the expected object in errors.
+func (p *Parser) scanQualifiedName(what string) (QualifiedName, error) {
+	var parts []string
+	for {
+		tok, litr := p.scanIdent()
+		if tok != IDENT {
+			return QualifiedName{}, fmt.Errorf("found %q, expected %s", litr, what)
+		}
+		parts = append(parts, litr)
+
+		if len(parts) == 3 || !p.scanOptional(DOT) {
+			break
+		}
+	}
+
+	n := QualifiedName{Name: parts[len(parts)-1]}
+	if len(parts) > 1 {
+		n.Schema = parts[len(parts)-2]
+	}
+	if len(parts) > 2 {
+		n.Catalog = parts[0]
+	}
+	return n, nil
+}
+
+// scanTableName parses a possibly qualified table name. After a USE seen by
+// Parse or a DumpReader, the name of a table of the database in use is read
+// without its database, as the Schema keys it.
+func (p *Parser) scanTableName() (string, error) {
+	n, err := p.scanQualifiedName("table name")
+	if err != nil {
+		return "", err
+	}
+	return relative(n, p.database), nil
+}
+
+// Table returns the table with the qualified name, whichever database is in
+// use, or nil.
+func (s Schema) Table(name QualifiedName) *Table {
+	for _, table := range s {
+		if table.QualifiedName() == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// Databases returns the databases of the schema's tables, in sorted order.
+func (s Schema) Databases() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, table := range s {
+		if db := table.Database; db != "" && !seen[db] {
+			seen[db] = true
+			names = append(names, db)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Database returns the tables of the database name keyed by their names. An
+// empty name selects the tables without a database.
+func (s Schema) Database(name string) Schema {
+	tables := make(Schema)
+	for _, table := range s {
+		if table.Database == name {
+			tables[table.Name] = table
+		}
+	}
+	return tables
+}
+
+// use keys the tables by their names after USE database.
+func (s Schema) use(database string) {
+	var tables []*Table
+	for key, table := range s {
+		if table.Database != "" {
+			tables = append(tables, table)
+			delete(s, key)
+		}
+	}
+	for _, table := range tables {
+		s[relative(table.QualifiedName(), database)] = table
+	}
+}
+
+// CreateDatabaseStatement is CREATE {DATABASE|SCHEMA} [IF NOT EXISTS] name [options].
+type CreateDatabaseStatement struct {
+	Name        string
+	IfNotExists bool
+	Charset     string
+	Collation   string
+	Options     map[string]string // every option, keyed by its lower-cased name
+}
+
+// DropDatabaseStatement is DROP {DATABASE|SCHEMA} [IF EXISTS] name.
+type DropDatabaseStatement struct {
+	Name     string
+	IfExists bool
+}
+
+func (*CreateDatabaseStatement) stmt() {}
+func (*DropDatabaseStatement) stmt()   {}
+
+// parseCreateDatabase parses what follows CREATE DATABASE.
+func (p *Parser) parseCreateDatabase() (*CreateDatabaseStatement, error) {
+	stmt := &CreateDatabaseStatement{Options: make(map[string]string)}
+
+	var err error
+	if stmt.IfNotExists, err = p.scanIfNotExists(); err != nil {
+		return nil, err
+	}
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected database name", litr)
+	}
+	stmt.Name = litr
+
+	for {
+		if tok, _ := p.scanIgnoreWhiteSpace(); tok == SEMI_COLON || tok == EOF {
+			p.unScan()
+			return stmt, nil
+		}
+		p.unScan()
+
+		name, value, err := p.scanTableOption()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Options[name] = value
+		switch name {
+		case "charset":
+			stmt.Charset = value
+		case "collate":
+			stmt.Collation = value
+		}
+	}
+}
+
+// parseDropDatabase parses what follows DROP DATABASE.
+func (p *Parser) parseDropDatabase() (*DropDatabaseStatement, error) {
+	stmt := &DropDatabaseStatement{}
+
+	var err error
+	if stmt.IfExists, err = p.scanIfExists(); err != nil {
+		return nil, err
+	}
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected database name", litr)
+	}
+	stmt.Name = litr
+
+	return stmt, nil
+}
+
+// dropDatabase removes the tables of a dropped database.
+func (s Schema) dropDatabase(stmt *DropDatabaseStatement) {
+	for key, table := range s {
+		if table.Database == stmt.Name {
+			delete(s, key)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* DROP TABLE, TRUNCATE and RENAME TABLE statements */
+
+// DropTableStatement is DROP [TEMPORARY] TABLE [IF EXISTS] name [, name ...] [RESTRICT|CASCADE].
+type DropTableStatement struct {
+	Temporary bool
+	IfExists  bool
+	Tables    []string
+	Restrict  bool
+	Cascade   bool
+}
+
+// TruncateStatement is TRUNCATE [TABLE] name.
+type TruncateStatement struct {
+	Table string
+}
+
+// TableRename is one old_name TO new_name pair of RENAME TABLE.
+type TableRename struct {
+	From string
+	To   string
+}
+
+// RenameTableStatement is RENAME TABLE old TO new [, old TO new ...].
+type RenameTableStatement struct {
+	Tables []*TableRename
+}
+
+func (*DropTableStatement) stmt()   {}
+func (*TruncateStatement) stmt()    {}
+func (*RenameTableStatement) stmt() {}
+
+// parseDropTable parses [TEMPORARY] TABLE ... after DROP.
+func (p *Parser) parseDropTable() (*DropTableStatement, error) {
+	stmt := &DropTableStatement{Temporary: p.scanOptional(TEMPORARY)}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != TABLE {
+		return nil, fmt.Errorf("found %q, expected TABLE", litr)
+	}
+
+	var err error
+	if stmt.IfExists, err = p.scanIfExists(); err != nil {
+		return nil, err
+	}
+
+	for {
+		name, err := p.scanTableName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Tables = append(stmt.Tables, name)
+
+		if !p.scanOptional(COMMA) {
+			break
+		}
+	}
+
+	stmt.Restrict = p.scanOptional(RESTRICT)
+	stmt.Cascade = !stmt.Restrict && p.scanOptional(CASCADE)
+
+	return stmt, nil
+}
+
+func (p *Parser) parseTruncate() (*TruncateStatement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != TRUNCATE {
+		return nil, fmt.Errorf("found %q, expected TRUNCATE", litr)
+	}
+	p.scanOptional(TABLE)
+
+	name, err := p.scanTableName()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TruncateStatement{Table: name}, nil
+}
+
+func (p *Parser) parseRenameTable() (*RenameTableStatement, error) {
+	stmt := &RenameTableStatement{}
+
+	tok1, litr1 := p.scanIgnoreWhiteSpace()
+	tok2, litr2 := p.scanIgnoreWhiteSpace()
+	if tok1 != RENAME || (tok2 != TABLE && tok2 != TABLES) {
+		return nil, fmt.Errorf("found %q, expected RENAME TABLE", litr1+" "+litr2)
+	}
+
+	for {
+		from, err := p.scanTableName()
+		if err != nil {
+			return nil, err
+		}
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != TO {
+			return nil, fmt.Errorf("found %q, expected TO", litr)
+		}
+		to, err := p.scanTableName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Tables = append(stmt.Tables, &TableRename{From: from, To: to})
+
+		if !p.scanOptional(COMMA) {
+			return stmt, nil
+		}
+	}
+}
+
+// referPrimaryKeys makes the foreign keys of the table named name that name
+// no columns, as REFERENCES parent does, refer to the primary key of their
+// table when it is in the schema.
+func (s Schema) referPrimaryKeys(table *Table, name, database string) {
+	for _, c := range table.ConstraintList {
+		if c.RefColumns != nil {
+			continue
+		}
+		ref := relative(QualifiedName{Catalog: c.RefCatalog, Schema: c.RefSchema, Name: c.TableName}, database)
+		parent := s[ref]
+		if parent == nil || parent.PrimaryKey == nil {
+			continue
+		}
+		for _, column := range parent.PrimaryKey.Columns {
+			c.RefColumns = append(c.RefColumns, column.Name)
+		}
+		c.ColumnName = c.RefColumns[0]
+	}
+}
+
+// ownerOnly reports whether specs only change the owner, as OWNER TO does.
+func ownerOnly(specs []*AlterSpec) bool {
+	for _, spec := range specs {
+		if spec.Action != "OPTION" || spec.Name != "owner" {
+			return false
+		}
+	}
+	return len(specs) > 0
+}
+
+// copy returns a copy of the table named name, as made by CREATE TABLE ...
+// LIKE: columns, indexes, checks and options are copied but foreign keys and
+// the AUTO_INCREMENT start are not, and generated check names are renamed
+// after the new table.
+func (table *Table) copy(name string) *Table {
+	c := newTable(name)
+
+	for _, column := range table.ColumnList {
+		col := *column
+		col.Members = append([]string(nil), column.Members...)
+		c.addColumn(&col)
+	}
+	for _, index := range table.IndexList {
+		idx := *index
+		idx.Columns = nil
+		for _, column := range index.Columns {
+			ic := *column
+			idx.Columns = append(idx.Columns, &ic)
+		}
+		c.addIndex(&idx)
+	}
+	for _, check := range table.CheckList {
+		chk := *check
+		if strings.HasPrefix(chk.Name, table.Name+"_chk_") {
+			chk.Name = ""
+		}
+		c.addCheck(&chk)
+	}
+
+	for k, v := range table.Extras {
+		if k != "auto_increment" {
+			c.Extras[k] = v
+		}
+	}
+	c.Engine, c.Charset, c.Collation = table.Engine, table.Charset, table.Collation
+	c.RowFormat, c.Comment, c.KeyBlockSize = table.RowFormat, table.Comment, table.KeyBlockSize
+	c.Partitioning = table.Partitioning.copy()
+
+	return c
+}
+
+// clone returns a copy of the table sharing nothing that altering it changes,
+// i.e. everything but expressions, views and the bodies of triggers.
+func (table *Table) clone() *Table {
+	c := *table
+	c.Columns, c.ColumnList = make(map[string]*Column), nil
+	for _, column := range table.ColumnList {
+		col := *column
+		col.Members = append([]string(nil), column.Members...)
+		c.Columns[col.Name] = &col
+		c.ColumnList = append(c.ColumnList, &col)
+	}
+
+	c.PrimaryKey, c.UniqueKeys, c.Keys, c.IndexList = nil, make(map[string]*Index), make(map[string]*Index), nil
+	for _, index := range table.IndexList {
+		idx := *index
+		idx.Columns = nil
+		for _, column := range index.Columns {
+			ic := *column
+			idx.Columns = append(idx.Columns, &ic)
+		}
+		switch {
+		case index == table.PrimaryKey:
+			c.PrimaryKey = &idx
+		case table.UniqueKeys[index.Name] == index:
+			c.UniqueKeys[idx.Name] = &idx
+		default:
+			c.Keys[idx.Name] = &idx
+		}
+		c.IndexList = append(c.IndexList, &idx)
+	}
+
+	c.Constraints, c.ConstraintList = make(map[string]*Constraint), nil
+	for _, constraint := range table.ConstraintList {
+		con := *constraint
+		con.Columns = append([]string(nil), constraint.Columns...)
+		con.RefColumns = append([]string(nil), constraint.RefColumns...)
+		c.Constraints[con.Index] = &con
+		c.ConstraintList = append(c.ConstraintList, &con)
+	}
+
+	c.Checks, c.CheckList = make(map[string]*CheckConstraint), nil
+	for _, check := range table.CheckList {
+		chk := *check
+		c.Checks[chk.Name] = &chk
+		c.CheckList = append(c.CheckList, &chk)
+	}
+
+	c.Extras = make(map[string]string)
+	for k, v := range table.Extras {
+		c.Extras[k] = v
+	}
+	c.Partitioning = table.Partitioning.copy()
+	c.Triggers = nil
+	for _, trigger := range table.Triggers {
+		trg := *trigger
+		c.Triggers = append(c.Triggers, &trg)
+	}
+	return &c
+}
+
+// Apply updates the schema with the effect of a DDL statement read before any
+// USE. Dropping a table that does not exist is not an error; statements that
+// do not change table definitions are ignored. USE keys the tables of its
+// database by their unqualified names and the others by their qualified ones.
+//
+// A statement read after USE resolves its table names in that database, so
+// it is applied by ApplyIn with the Parser's Database, as Parse does.
+func (s Schema) Apply(stmt Statement) error {
+	return s.apply(stmt, "")
+}
+
+// ApplyIn is Apply for a statement read after USE database.
+func (s Schema) ApplyIn(stmt Statement, database string) error {
+	return s.apply(stmt, database)
+}
+
+// apply is ApplyIn.
+func (s Schema) apply(stmt Statement, database string) error {
+	switch stmt := stmt.(type) {
+	case *UseStatement:
+		s.use(stmt.Database)
+
+	case *CreateTableStatement:
+		name := relative(stmt.Table.QualifiedName(), database)
+		if stmt.IfNotExists && s[name] != nil {
+			return nil
+		}
+		if stmt.Like == "" {
+			s[name] = stmt.Table
+			s.referPrimaryKeys(stmt.Table, name, database)
+			break
+		}
+		source := s[stmt.Like]
+		if source == nil {
+			return fmt.Errorf("cannot create %q like %q, table does not exist", name, stmt.Like)
+		}
+		table := source.copy(stmt.Table.Name)
+		table.Catalog, table.Database = stmt.Table.Catalog, stmt.Table.Database
+		table.Temporary = stmt.Temporary
+		s[name] = table
+
+	case *DropTableStatement:
+		for _, name := range stmt.Tables {
+			delete(s, name)
+		}
+
+	case *DropDatabaseStatement:
+		s.dropDatabase(stmt)
+
+	case *RenameTableStatement:
+		// Renames are applied in order, so a TO b, b TO c renames a to c.
+		for _, rename := range stmt.Tables {
+			table := s[rename.From]
+			if table == nil {
+				return fmt.Errorf("cannot rename %q, table does not exist", rename.From)
+			}
+			// a name without a database keeps the table in its own
+			to := absolute(rename.To, database)
+			if to.Schema == "" {
+				to.Catalog, to.Schema = table.Catalog, table.Database
+			}
+			name := relative(to, database)
+			if s[name] != nil {
+				return fmt.Errorf("cannot rename %q to %q, table already exists", rename.From, rename.To)
+			}
+			delete(s, rename.From)
+			table.rename(to)
+			for _, trigger := range table.Triggers {
+				trigger.Table = name
+			}
+			s[name] = table
+		}
+
+	case *CreateViewStatement:
+		return s.createView(stmt, database)
+
+	case *DropViewStatement:
+		return s.dropView(stmt)
+
+	case *CreateTriggerStatement:
+		return s.createTrigger(stmt)
+
+	case *DropTriggerStatement:
+		return s.dropTrigger(stmt)
+
+	case *CreateIndexStatement:
+		return s.createIndex(stmt)
+
+	case *CommentStatement:
+		return s.comment(stmt)
+
+	case *DropIndexStatement:
+		return s.dropIndex(stmt)
+
+	case *AlterTableStatement:
+		table := s[stmt.Table]
+		if table == nil && (stmt.IfExists || ownerOnly(stmt.Specs)) {
+			// pg_dump also changes the owner of sequences by ALTER TABLE;
+			// they are not kept in the Schema
+			return nil
+		}
+		if table == nil {
+			return fmt.Errorf("cannot alter %q, table does not exist", stmt.Table)
+		}
+		for _, spec := range stmt.Specs {
+			if spec.Action == "RENAME TABLE" && spec.NewName != stmt.Table && s[spec.NewName] != nil {
+				return fmt.Errorf("cannot rename %q to %q, table already exists", stmt.Table, spec.NewName)
+			}
+		}
+		altered := table.clone()
+		if err := altered.Alter(stmt.Specs...); err != nil {
+			return err
+		}
+		delete(s, stmt.Table)
+		name := relative(altered.QualifiedName(), database)
+		s[name] = altered
+		s.referPrimaryKeys(altered, name, database)
+	}
+
+	return nil
+}
@@ -0,0 +1,377 @@
+package SQLParser
+
+/* SQL dialects */
+
+// Dialect describes the lexical rules of a SQL engine: its keywords, which of
+// them are reserved, how identifiers are quoted, which strings take backslash
+// escapes, and the names of its types and its operators.
+//
+// The built-in dialects are MySQL, the default, PostgreSQL, SQLite and ANSI.
+// Another engine is read by a Dialect of its own, which may embed a built-in
+// one to change some of its rules. Its statements are read with the grammar
+// of the built-in dialect of the same Name, or of MySQL, unless the Dialect
+// also has the methods of the grammar interface, as the built-in ones do.
+type Dialect interface {
+	// Name returns the name of the dialect, such as "mysql".
+	Name() string
+
+	// Keyword returns the token of an upper-cased word and whether the
+	// word is a keyword; other words are read as identifiers.
+	Keyword(word string) (Tokens, bool)
+
+	// Reserved reports whether a keyword may not be used as a plain
+	// identifier, e.g. a column named level.
+	Reserved(tok Tokens) bool
+
+	// IdentQuote returns the quote closing an identifier opened by ch, or
+	// 0 when ch does not quote identifiers.
+	IdentQuote(ch rune) rune
+
+	// StringEscapes reports whether backslashes escape characters in the
+	// strings written with prefix: 0 for plain 'strings', 'E' for E'strings'.
+	StringEscapes(prefix rune) bool
+
+	// Type returns the Column type of a lower-cased type name, such as
+	// "int" for "integer", and whether the dialect has the type.
+	Type(name string) (string, bool)
+
+	// Operator reports whether the dialect has an operator, one of the
+	// tokens from LT to DOUBLE_COLON.
+	Operator(tok Tokens) bool
+}
+
+// grammar holds the switches of the grammar a Dialect's statements are read
+// with. It is optional, see Dialect.
+type grammar interface {
+	// DollarQuotes reports whether strings may be $$dollar$$ or
+	// $tag$dollar$tag$ quoted.
+	DollarQuotes() bool
+
+	// TypeSyntax returns how column types are written.
+	TypeSyntax() TypeSyntax
+
+	// IndexExpressions reports whether indexed columns may be function
+	// calls such as lower(name) ordered NULLS FIRST or LAST, and indexes
+	// may use any method, such as USING gin.
+	IndexExpressions() bool
+
+	// IndexCollation reports whether indexed columns may be followed by
+	// COLLATE name.
+	IndexCollation() bool
+
+	// ConflictClauses reports whether NOT NULL, PRIMARY KEY and UNIQUE may
+	// be followed by ON CONFLICT resolution.
+	ConflictClauses() bool
+
+	// RowidTables reports whether tables have the options WITHOUT ROWID and
+	// STRICT, and a column's PRIMARY KEY may be ordered ASC or DESC.
+	RowidTables() bool
+
+	// NamedPrimaryKeys reports whether a primary key keeps the name of its
+	// CONSTRAINT; MySQL names every primary key PRIMARY.
+	NamedPrimaryKeys() bool
+
+	// TriggerSyntax returns how triggers are written.
+	TriggerSyntax() TriggerSyntax
+
+	// SkipsStatement reports whether Parse skips the statements starting
+	// with an upper-cased word, such as the settings of a dump tool.
+	SkipsStatement(word string) bool
+
+	// SkipsUnknownObjects reports whether Parse skips the CREATE statements
+	// of objects it does not read, such as extensions or virtual tables,
+	// rather than failing.
+	SkipsUnknownObjects() bool
+}
+
+// TypeSyntax is how the column types of a dialect are written.
+type TypeSyntax int
+
+const (
+	// KeywordTypes are the type keywords of the dialect with (size),
+	// (precision, scale) or ('member', ...) arguments, as in MySQL.
+	KeywordTypes TypeSyntax = iota
+
+	// NamedTypes are also other type names, possibly qualified, followed
+	// by WITH TIME ZONE and [] array dimensions, as in PostgreSQL.
+	NamedTypes
+
+	// DeclaredTypes are any sequence of names, or none, as in SQLite; the
+	// Affinity of the column follows from the declared type.
+	DeclaredTypes
+)
+
+// TriggerSyntax is how the triggers of a dialect are written.
+type TriggerSyntax int
+
+const (
+	// MySQLTriggers fire BEFORE or AFTER an event FOR EACH ROW, may be
+	// ordered by FOLLOWS or PRECEDES and have a statement or a BEGIN ...
+	// END body.
+	MySQLTriggers TriggerSyntax = iota
+
+	// SQLiteTriggers may also fire INSTEAD OF an event, or before it when
+	// no timing is given, on the UPDATE OF some columns, and WHEN a
+	// condition holds; FOR EACH ROW is optional.
+	SQLiteTriggers
+
+	// UnreadTriggers are skipped like functions and procedures, whose
+	// bodies are not read, as the triggers of PostgreSQL.
+	UnreadTriggers
+)
+
+var (
+	// MySQL is the default dialect, which also reads MariaDB.
+	MySQL Dialect = &builtin{
+		name:       "mysql",
+		keywords:   keywords,
+		unreserved: unreserved,
+		quotes:     map[rune]rune{'`': '`'},
+		escapes:    map[rune]bool{0: true},
+		types:      mysqlTypes,
+		operators:  operatorSet(DOUBLE_COLON),
+	}
+
+	// PostgreSQL reads "quoted" identifiers, E'escaped' and $$dollar$$
+	// quoted strings, x::type casts, arrays and the DDL of pg_dump.
+	PostgreSQL Dialect = &builtin{
+		name:             "postgresql",
+		keywords:         keywordSet(mysqlKeywords, map[string]Tokens{"TEMP": TEMPORARY}),
+		unreserved:       ordinaryWords,
+		quotes:           map[rune]rune{'"': '"'},
+		escapes:          map[rune]bool{'E': true},
+		dollarQuotes:     true,
+		types:            withTypes(mysqlTypes, postgresTypes),
+		typeSyntax:       NamedTypes,
+		operators:        operatorSet(),
+		indexExpressions: true,
+		indexCollation:   true,
+		namedPrimaryKeys: true,
+		triggers:         UnreadTriggers,
+		skips:            map[string]bool{"SELECT": true, "GRANT": true, "REVOKE": true, "SET": true},
+		skipsUnknown:     true,
+	}
+
+	// SQLite reads "quoted", `quoted` and [bracketed] identifiers, the type
+	// names of its type affinity rules and the table options of sqlite3
+	// .schema.
+	SQLite Dialect = &builtin{
+		name:             "sqlite",
+		keywords:         keywordSet(mysqlKeywords, map[string]Tokens{"TEMP": TEMPORARY, "AUTOINCREMENT": AUTO_INCREMENT}),
+		unreserved:       ordinaryWords,
+		quotes:           map[rune]rune{'"': '"', '`': '`', '[': ']'},
+		escapes:          map[rune]bool{},
+		types:            mysqlTypes,
+		typeSyntax:       DeclaredTypes,
+		operators:        operatorSet(ASSIGN, DOUBLE_COLON),
+		indexCollation:   true,
+		namedPrimaryKeys: true,
+		conflictClauses:  true,
+		rowidTables:      true,
+		triggers:         SQLiteTriggers,
+		skips:            map[string]bool{"PRAGMA": true},
+		skipsUnknown:     true,
+	}
+
+	// ANSI reads standard SQL: "quoted" identifiers, strings without
+	// backslash escapes and the types of the standard.
+	ANSI Dialect = &builtin{
+		name:             "ansi",
+		keywords:         keywordSet(mysqlKeywords, nil),
+		unreserved:       unreserved,
+		quotes:           map[rune]rune{'"': '"'},
+		escapes:          map[rune]bool{},
+		types:            ansiTypes,
+		operators:        operatorSet(ASSIGN, DOUBLE_COLON),
+		indexCollation:   true,
+		namedPrimaryKeys: true,
+		skipsUnknown:     true,
+	}
+)
+
+// ordinaryWords are the keywords PostgreSQL and SQLite let be used as plain
+// identifiers: those MySQL does, and the words of MySQL's own statements and
+// options, such as LOCK TABLES ... READ or WRITE, SHOW and KEY.
+var ordinaryWords = tokenSet(unreserved,
+	KEY, INDEX, COMMENT, TABLES, RENAME, OPTION, PROCEDURE, CASCADE, RESTRICT,
+	RELEASE, READ, WRITE, LOCK, UNLOCK, TRUNCATE, SHOW, DESCRIBE, EXPLAIN, USE,
+	REVOKE)
+
+// mysqlKeywords are the keywords of MySQL the other built-in dialects read
+// as identifiers: its own types, column attributes and index kinds.
+var mysqlKeywords = []string{
+	"AUTO_INCREMENT", "UNSIGNED", "SIGNED", "ZEROFILL", "FULLTEXT", "SPATIAL",
+	"TINYINT", "MEDIUMINT", "FIXED", "TINYTEXT", "MEDIUMTEXT", "LONGTEXT",
+	"TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "YEAR", "DATETIME", "PERSIST", "PERSIST_ONLY",
+}
+
+// ansiTypes names the types of standard SQL.
+var ansiTypes = map[string]string{
+	"smallint":  "smallint",
+	"integer":   "int",
+	"int":       "int",
+	"bigint":    "bigint",
+	"decimal":   "decimal",
+	"dec":       "decimal",
+	"numeric":   "decimal",
+	"float":     "float",
+	"real":      "float",
+	"double":    "double",
+	"boolean":   "boolean",
+	"char":      "char",
+	"varchar":   "varchar",
+	"clob":      "text",
+	"binary":    "binary",
+	"varbinary": "varbinary",
+	"blob":      "blob",
+	"date":      "date",
+	"time":      "time",
+	"timestamp": "timestamp",
+}
+
+// builtin is the Dialect of the built-in dialects.
+type builtin struct {
+	name             string
+	keywords         map[string]Tokens
+	unreserved       map[Tokens]bool
+	quotes           map[rune]rune // opening quotes of identifiers to the closing ones
+	escapes          map[rune]bool // prefixes of strings with backslash escapes
+	dollarQuotes     bool
+	types            map[string]string
+	typeSyntax       TypeSyntax
+	operators        map[Tokens]bool
+	indexExpressions bool
+	indexCollation   bool
+	conflictClauses  bool
+	rowidTables      bool
+	namedPrimaryKeys bool
+	triggers         TriggerSyntax
+	skips            map[string]bool // first words of the statements Parse skips
+	skipsUnknown     bool
+}
+
+func (d *builtin) Name() string { return d.name }
+
+func (d *builtin) Keyword(word string) (Tokens, bool) {
+	tok, ok := d.keywords[word]
+	return tok, ok
+}
+
+func (d *builtin) Reserved(tok Tokens) bool { return isKeyword(tok) && !d.unreserved[tok] }
+
+func (d *builtin) IdentQuote(ch rune) rune { return d.quotes[ch] }
+
+func (d *builtin) StringEscapes(prefix rune) bool { return d.escapes[prefix] }
+
+func (d *builtin) DollarQuotes() bool { return d.dollarQuotes }
+
+func (d *builtin) Type(name string) (string, bool) {
+	typ, ok := d.types[name]
+	return typ, ok
+}
+
+func (d *builtin) TypeSyntax() TypeSyntax { return d.typeSyntax }
+
+func (d *builtin) Operator(tok Tokens) bool { return d.operators[tok] }
+
+func (d *builtin) IndexExpressions() bool { return d.indexExpressions }
+
+func (d *builtin) IndexCollation() bool { return d.indexCollation }
+
+func (d *builtin) ConflictClauses() bool { return d.conflictClauses }
+
+func (d *builtin) RowidTables() bool { return d.rowidTables }
+
+func (d *builtin) NamedPrimaryKeys() bool { return d.namedPrimaryKeys }
+
+func (d *builtin) TriggerSyntax() TriggerSyntax { return d.triggers }
+
+func (d *builtin) SkipsStatement(word string) bool { return d.skips[word] }
+
+func (d *builtin) SkipsUnknownObjects() bool { return d.skipsUnknown }
+
+// keywordSet returns the keywords of MySQL but the given ones, with the
+// added ones.
+func keywordSet(except []string, added map[string]Tokens) map[string]Tokens {
+	set := make(map[string]Tokens)
+	for word, tok := range keywords {
+		set[word] = tok
+	}
+	for _, word := range except {
+		delete(set, word)
+	}
+	for word, tok := range added {
+		set[word] = tok
+	}
+	return set
+}
+
+// tokenSet returns a set with the tokens of set and the given ones.
+func tokenSet(set map[Tokens]bool, toks ...Tokens) map[Tokens]bool {
+	c := make(map[Tokens]bool)
+	for tok := range set {
+		c[tok] = true
+	}
+	for _, tok := range toks {
+		c[tok] = true
+	}
+	return c
+}
+
+// operatorSet returns the set of all operators but the given ones.
+func operatorSet(except ...Tokens) map[Tokens]bool {
+	set := make(map[Tokens]bool)
+	for tok := LT; tok <= DOUBLE_COLON; tok++ {
+		set[tok] = true
+	}
+	for _, tok := range except {
+		delete(set, tok)
+	}
+	return set
+}
+
+// withTypes returns a type catalog with the types of all the given ones, the
+// later ones replacing the earlier.
+func withTypes(catalogs ...map[string]string) map[string]string {
+	types := make(map[string]string)
+	for _, catalog := range catalogs {
+		for name, typ := range catalog {
+			types[name] = typ
+		}
+	}
+	return types
+}
+
+// Option configures a Scanner, or the Scanner of a Parser.
+type Option func(*Scanner)
+
+// WithDialect reads the input in a dialect, MySQL by default.
+func WithDialect(dialect Dialect) Option {
+	return func(scan *Scanner) { scan.dialect = dialect }
+}
+
+// WithServerVersion executes versioned comments as a MySQL server of the
+// version does, see Scanner.SetServerVersion.
+func WithServerVersion(version int) Option {
+	return func(scan *Scanner) { scan.version = version }
+}
+
+// nonReserved reports whether tok is a keyword the dialect lets be used as
+// an identifier.
+func (scan *Scanner) nonReserved(tok Tokens) bool {
+	return isKeyword(tok) && !scan.dialect.Reserved(tok)
+}
+
+// grammar returns the grammar switches of the dialect; a dialect without
+// them has those of the built-in dialect of the same name, or of MySQL.
+func (scan *Scanner) grammar() grammar {
+	if g, ok := scan.dialect.(grammar); ok {
+		return g
+	}
+	for _, d := range []Dialect{PostgreSQL, SQLite, ANSI} {
+		if d.Name() == scan.dialect.Name() {
+			return d.(grammar)
+		}
+	}
+	return MySQL.(grammar)
+}
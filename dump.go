@@ -0,0 +1,107 @@
+package SQLParser
+
+import (
+	"fmt"
+	"io"
+)
+
+/* Reading mysqldump files */
+
+// Row is a row inserted into a table by an INSERT statement of a dump.
+type Row struct {
+	Table    string   // the name of the table, without its database
+	Database string   // the database of the table, if qualified or after USE
+	Columns  []string // columns named by the INSERT, or else those of the table
+	Values   []Expr
+}
+
+// DumpReader reads a mysqldump file: the statements defining the schema are
+// applied to a Schema and the rows of the INSERT statements, extended ones
+// included, are handed over one at a time without keeping them in memory.
+//
+// Versioned comments /*!NNNNN ... */ are executed as the latest MySQL does;
+// SetServerVersion changes that. After USE db, as written by mysqldump
+// --databases, the tables of db are keyed by their unqualified names and
+// those of the other databases as db.table, see Parser.Parse.
+type DumpReader struct {
+	p      *Parser
+	schema Schema
+}
+
+// NewDumpReader returns a DumpReader reading the dump from r.
+func NewDumpReader(r io.Reader) *DumpReader {
+	d := &DumpReader{p: NewParser(r), schema: make(Schema)}
+	d.p.SetServerVersion(999999)
+	return d
+}
+
+// SetServerVersion sets the MySQL version executing versioned comments, see
+// Scanner.SetServerVersion.
+func (d *DumpReader) SetServerVersion(version int) {
+	d.p.SetServerVersion(version)
+}
+
+// Read reads the dump to its end and returns its schema. row is called for
+// every inserted row in dump order; it may be nil to only read the schema.
+// An error returned by row stops the reading and is returned by Read, along
+// with the schema read so far.
+func (d *DumpReader) Read(row func(*Row) error) (Schema, error) {
+	for {
+		tok, _ := d.p.scanIgnoreWhiteSpace()
+		switch tok {
+		case EOF:
+			return d.schema, nil
+		case SEMI_COLON:
+			continue
+		case INSERT:
+			d.p.unScan()
+			if err := d.readInsert(row); err != nil {
+				return d.schema, err
+			}
+			continue
+		}
+
+		// SET, LOCK TABLES, ALTER TABLE ... DISABLE KEYS and other
+		// statements without effect on the schema are parsed and ignored
+		d.p.unScan()
+		stmt, err := d.p.ParseStatement()
+		if err != nil {
+			return d.schema, err
+		}
+		if stmt == nil {
+			return d.schema, nil
+		}
+		if err := d.schema.apply(stmt, d.p.database); err != nil {
+			return d.schema, err
+		}
+	}
+}
+
+// readInsert reads an INSERT statement, calling row for each of its rows.
+func (d *DumpReader) readInsert(row func(*Row) error) error {
+	insert := &InsertStatement{}
+	var columns []string
+
+	err := d.p.parseInsert(insert, func(values []Expr) error {
+		if columns == nil {
+			columns = insert.Columns
+			if table := d.schema[insert.TableName]; columns == nil && table != nil {
+				for _, column := range table.ColumnList {
+					columns = append(columns, column.Name)
+				}
+			}
+		}
+		if columns != nil && len(values) != len(columns) {
+			return fmt.Errorf("found %d values, expected %d for %q", len(values), len(columns), insert.TableName)
+		}
+		if row == nil {
+			return nil
+		}
+		name := absolute(insert.TableName, d.p.database)
+		return row(&Row{Table: name.Name, Database: name.Schema, Columns: columns, Values: values})
+	})
+	if err != nil {
+		return err
+	}
+	return d.p.scanStatementEnd()
+}
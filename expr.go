@@ -0,0 +1,734 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/* SQL Expressions */
+
+// Expr is a node of a parsed SQL expression.
+type Expr interface {
+	expr()
+	String() string
+}
+
+// Ident is a column or other unquoted name used as a value, possibly
+// qualified as table.column or db.table.column.
+type Ident struct {
+	Name   string
+	Table  string
+	Schema string
+}
+
+// StringLit is a quoted string literal.
+type StringLit struct {
+	Value   string
+	Charset string // character set of an introducer such as _binary, without the underscore
+}
+
+// NumberLit is a numeric literal, kept in its source form.
+type NumberLit struct {
+	Value string
+}
+
+// BitLit is a bit-value literal such as b'0101'.
+type BitLit struct {
+	Value string // the binary digits
+}
+
+// HexLit is a hexadecimal literal such as 0x0A0B or X'0A0B'.
+type HexLit struct {
+	Value string // the hexadecimal digits
+}
+
+// NullLit is the NULL literal.
+type NullLit struct{}
+
+// BoolLit is TRUE or FALSE.
+type BoolLit struct {
+	Value bool
+}
+
+// Variable is a user variable (@name) or a system variable (@@scope.name or a
+// bare name in SET).
+type Variable struct {
+	Name   string
+	System bool
+	Scope  string // "GLOBAL", "SESSION", "PERSIST", ... or empty
+}
+
+// Placeholder is a bind parameter written as ?, $1 or :name.
+type Placeholder struct {
+	Text    string // as written
+	Ordinal int    // position of ? in the statement or the number of $n; 0 for :name
+	Name    string // name of :name
+}
+
+// UnaryExpr is NOT x, -x or +x.
+type UnaryExpr struct {
+	Op Tokens
+	X  Expr
+}
+
+// BinaryExpr is an arithmetic, comparison, LIKE or logical operation.
+type BinaryExpr struct {
+	Op  Tokens
+	LHS Expr
+	RHS Expr
+}
+
+// ParenExpr is a parenthesized expression.
+type ParenExpr struct {
+	X Expr
+}
+
+// TupleExpr is a parenthesized list of expressions such as (1, 'a').
+type TupleExpr struct {
+	Exprs []Expr
+}
+
+// Call is a function call such as COUNT(*) or CONCAT(a, b).
+type Call struct {
+	Name string
+	Args []Expr
+	Star bool // COUNT(*)
+}
+
+// CastExpr is a PostgreSQL cast x::type.
+type CastExpr struct {
+	X    Expr
+	Type string // as the type of a column is written, e.g. character varying(20) or text[]
+}
+
+// ArrayExpr is a PostgreSQL array constructor ARRAY[x, ...].
+type ArrayExpr struct {
+	Elems []Expr
+}
+
+// IsNullExpr is x IS [NOT] NULL.
+type IsNullExpr struct {
+	X   Expr
+	Not bool
+}
+
+// InExpr is x [NOT] IN (list).
+type InExpr struct {
+	X    Expr
+	Not  bool
+	List []Expr
+}
+
+// BetweenExpr is x [NOT] BETWEEN low AND high.
+type BetweenExpr struct {
+	X    Expr
+	Not  bool
+	Low  Expr
+	High Expr
+}
+
+func (*Ident) expr()       {}
+func (*StringLit) expr()   {}
+func (*NumberLit) expr()   {}
+func (*BitLit) expr()      {}
+func (*HexLit) expr()      {}
+func (*NullLit) expr()     {}
+func (*BoolLit) expr()     {}
+func (*Variable) expr()    {}
+func (*Placeholder) expr() {}
+func (*UnaryExpr) expr()   {}
+func (*BinaryExpr) expr()  {}
+func (*ParenExpr) expr()   {}
+func (*TupleExpr) expr()   {}
+func (*Call) expr()        {}
+func (*CastExpr) expr()    {}
+func (*ArrayExpr) expr()   {}
+func (*IsNullExpr) expr()  {}
+func (*InExpr) expr()      {}
+func (*BetweenExpr) expr() {}
+
+// operators maps operator tokens to their SQL spelling.
+var operators = map[Tokens]string{
+	EQUAL:     "=",
+	LT:        "<",
+	GT:        ">",
+	LTE:       "<=",
+	GTE:       ">=",
+	NOT_EQUAL: "<>",
+	PLUS:      "+",
+	MINUS:     "-",
+	ASTERISK:  "*",
+	SLASH:     "/",
+	PERCENT:   "%",
+	AND:       "AND",
+	OR:        "OR",
+	NOT:       "NOT",
+	LIKE:      "LIKE",
+}
+
+func (e *NumberLit) String() string   { return e.Value }
+func (e *BitLit) String() string      { return "b'" + e.Value + "'" }
+func (e *HexLit) String() string      { return "X'" + e.Value + "'" }
+func (e *NullLit) String() string     { return "NULL" }
+func (e *ParenExpr) String() string   { return "(" + e.X.String() + ")" }
+func (e *Placeholder) String() string { return e.Text }
+
+func (e *Ident) String() string {
+	return QualifiedName{Catalog: e.Schema, Schema: e.Table, Name: e.Name}.String()
+}
+
+func (e *StringLit) String() string {
+	s := "'" + strings.Replace(e.Value, "'", "''", -1) + "'"
+	if e.Charset != "" {
+		return "_" + e.Charset + s
+	}
+	return s
+}
+
+func (e *BoolLit) String() string {
+	if e.Value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (e *Variable) String() string {
+	if !e.System {
+		return "@" + e.Name
+	}
+	if e.Scope != "" {
+		return "@@" + e.Scope + "." + e.Name
+	}
+	return "@@" + e.Name
+}
+
+func (e *UnaryExpr) String() string {
+	if e.Op == NOT {
+		return "NOT " + e.X.String()
+	}
+	return operators[e.Op] + e.X.String()
+}
+
+func (e *BinaryExpr) String() string {
+	return e.LHS.String() + " " + operators[e.Op] + " " + e.RHS.String()
+}
+
+func (e *TupleExpr) String() string { return "(" + joinExprs(e.Exprs) + ")" }
+
+func (e *Call) String() string {
+	if e.Star {
+		return e.Name + "(*)"
+	}
+	return e.Name + "(" + joinExprs(e.Args) + ")"
+}
+
+func (e *CastExpr) String() string  { return e.X.String() + "::" + e.Type }
+func (e *ArrayExpr) String() string { return "ARRAY[" + joinExprs(e.Elems) + "]" }
+
+func (e *IsNullExpr) String() string {
+	if e.Not {
+		return e.X.String() + " IS NOT NULL"
+	}
+	return e.X.String() + " IS NULL"
+}
+
+func (e *InExpr) String() string {
+	if e.Not {
+		return e.X.String() + " NOT IN (" + joinExprs(e.List) + ")"
+	}
+	return e.X.String() + " IN (" + joinExprs(e.List) + ")"
+}
+
+func (e *BetweenExpr) String() string {
+	op := " BETWEEN "
+	if e.Not {
+		op = " NOT BETWEEN "
+	}
+	return e.X.String() + op + e.Low.String() + " AND " + e.High.String()
+}
+
+func joinExprs(list []Expr) string {
+	s := make([]string, len(list))
+	for i, e := range list {
+		s[i] = e.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// ParseExpr parses a single expression.
+func (p *Parser) ParseExpr() (Expr, error) {
+	return p.parseOr()
+}
+
+func (p *Parser) parseOr() (Expr, error) {
+	lhs, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.scanOptional(OR) {
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &BinaryExpr{Op: OR, LHS: lhs, RHS: rhs}
+	}
+	return lhs, nil
+}
+
+func (p *Parser) parseAnd() (Expr, error) {
+	lhs, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.scanOptional(AND) {
+		rhs, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &BinaryExpr{Op: AND, LHS: lhs, RHS: rhs}
+	}
+	return lhs, nil
+}
+
+func (p *Parser) parseNot() (Expr, error) {
+	if p.scanOptional(NOT) {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Op: NOT, X: x}, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *Parser) parsePredicate() (Expr, error) {
+	x, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		switch tok {
+		case EQUAL, LT, GT, LTE, GTE, NOT_EQUAL:
+			rhs, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			x = &BinaryExpr{Op: tok, LHS: x, RHS: rhs}
+
+		case IS:
+			not := p.scanOptional(NOT)
+			if tok, litr := p.scanIgnoreWhiteSpace(); tok != NULL {
+				return nil, fmt.Errorf("found %q, expected NULL", litr)
+			}
+			x = &IsNullExpr{X: x, Not: not}
+
+		case NOT:
+			tok, litr = p.scanIgnoreWhiteSpace()
+			if tok != IN && tok != LIKE && tok != BETWEEN {
+				return nil, fmt.Errorf("found %q, expected IN, LIKE or BETWEEN", litr)
+			}
+			p.unScan()
+			if x, err = p.parseNegatable(x, true); err != nil {
+				return nil, err
+			}
+
+		case IN, LIKE, BETWEEN:
+			p.unScan()
+			if x, err = p.parseNegatable(x, false); err != nil {
+				return nil, err
+			}
+
+		default:
+			p.unScan()
+			return x, nil
+		}
+	}
+}
+
+// parseNegatable parses the IN, LIKE or BETWEEN predicate applied to x.
+func (p *Parser) parseNegatable(x Expr, not bool) (Expr, error) {
+	switch tok, _ := p.scanIgnoreWhiteSpace(); tok {
+	case IN:
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH {
+			return nil, fmt.Errorf("found %q, expected (", litr)
+		}
+		list, err := p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+		return &InExpr{X: x, Not: not, List: list}, nil
+
+	case LIKE:
+		pattern, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		var e Expr = &BinaryExpr{Op: LIKE, LHS: x, RHS: pattern}
+		if not {
+			e = &UnaryExpr{Op: NOT, X: e}
+		}
+		return e, nil
+
+	default:
+		low, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != AND {
+			return nil, fmt.Errorf("found %q, expected AND", litr)
+		}
+		high, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &BetweenExpr{X: x, Not: not, Low: low, High: high}, nil
+	}
+}
+
+func (p *Parser) parseAdditive() (Expr, error) {
+	lhs, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, _ := p.scanIgnoreWhiteSpace()
+		if tok != PLUS && tok != MINUS {
+			p.unScan()
+			return lhs, nil
+		}
+		rhs, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &BinaryExpr{Op: tok, LHS: lhs, RHS: rhs}
+	}
+}
+
+func (p *Parser) parseMultiplicative() (Expr, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, _ := p.scanIgnoreWhiteSpace()
+		if tok != ASTERISK && tok != SLASH && tok != PERCENT {
+			p.unScan()
+			return lhs, nil
+		}
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &BinaryExpr{Op: tok, LHS: lhs, RHS: rhs}
+	}
+}
+
+func (p *Parser) parseUnary() (Expr, error) {
+	tok, _ := p.scanIgnoreWhiteSpace()
+	if tok != MINUS && tok != PLUS {
+		p.unScan()
+		return p.parsePrimary()
+	}
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &UnaryExpr{Op: tok, X: x}, nil
+}
+
+// parsePrimary parses an operand followed by any number of PostgreSQL
+// ::type casts.
+func (p *Parser) parsePrimary() (Expr, error) {
+	x, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for p.scanOptional(DOUBLE_COLON) {
+		column := &Column{}
+		if err := p.scanType(column); err != nil {
+			return nil, err
+		}
+		x = &CastExpr{X: x, Type: typeString(column)}
+	}
+	return x, nil
+}
+
+// scanAdjacentStrings returns the string litr joined with the strings that
+// directly follow it, as 'a' 'b' is the string 'ab'; mysqldump writes
+// GTID_PURGED=/*!80000 '+'*/ 'uuid:1-5'.
+func (p *Parser) scanAdjacentStrings(litr string) string {
+	for {
+		tok, litr1 := p.scanIgnoreWhiteSpace()
+		if tok != STRING {
+			p.unScan()
+			return litr
+		}
+		litr += litr1
+	}
+}
+
+func (p *Parser) parseOperand() (Expr, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+
+	switch tok {
+	case STRING:
+		return &StringLit{Value: p.scanAdjacentStrings(litr)}, nil
+	case SIZE:
+		return &NumberLit{Value: litr}, nil
+	case BITSTRING:
+		return &BitLit{Value: litr}, nil
+	case HEXSTRING:
+		return &HexLit{Value: litr}, nil
+	case NULL:
+		return &NullLit{}, nil
+	case TRUE, FALSE:
+		return &BoolLit{Value: tok == TRUE}, nil
+	case VARIABLE:
+		return parseVariable(litr), nil
+	case PARAM:
+		return p.parsePlaceholder(litr), nil
+	case OPEN_PARENTH:
+		x, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH {
+			return nil, fmt.Errorf("found %q, expected )", litr)
+		}
+		return &ParenExpr{X: x}, nil
+	}
+
+	if tok != IDENT && !isKeyword(tok) {
+		return nil, fmt.Errorf("found %q, expected expression", litr)
+	}
+	if isWord(tok, litr, "ARRAY") && p.scanOptional(OPEN_BRACKET) {
+		return p.parseArray()
+	}
+
+	// A character set introducer such as _binary may precede a literal.
+	if tok == IDENT && strings.HasPrefix(litr, "_") {
+		switch tok1, litr1 := p.scanIgnoreWhiteSpace(); tok1 {
+		case STRING:
+			return &StringLit{Value: p.scanAdjacentStrings(litr1), Charset: litr[1:]}, nil
+		case HEXSTRING:
+			return &HexLit{Value: litr1}, nil
+		case BITSTRING:
+			return &BitLit{Value: litr1}, nil
+		}
+		p.unScan()
+	}
+
+	// Keywords such as IF or DATE may name a function; otherwise only
+	// identifiers and unreserved keywords are values. CURRENT_TIMESTAMP may
+	// be called without parentheses.
+	if !p.scanOptional(OPEN_PARENTH) {
+		if tok == CURRENT_TIMESTAMP {
+			return &Call{Name: "CURRENT_TIMESTAMP"}, nil
+		}
+		if tok != IDENT && !p.sc.nonReserved(tok) && tok != DEFAULT {
+			return nil, fmt.Errorf("found %q, expected expression", litr)
+		}
+		ident := &Ident{Name: litr}
+		for ident.Schema == "" && p.scanOptional(DOT) {
+			// t.* stands for all the columns of t, as in SELECT t.*
+			if p.scanOptional(ASTERISK) {
+				ident.Schema, ident.Table, ident.Name = ident.Table, ident.Name, "*"
+				break
+			}
+			tok, litr := p.scanIdent()
+			if tok != IDENT {
+				return nil, fmt.Errorf("found %q, expected column name", litr)
+			}
+			ident.Schema, ident.Table, ident.Name = ident.Table, ident.Name, litr
+		}
+		return ident, nil
+	}
+
+	call := &Call{Name: strings.ToUpper(litr)}
+	if p.scanOptional(ASTERISK) {
+		call.Star = true
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH {
+			return nil, fmt.Errorf("found %q, expected )", litr)
+		}
+		return call, nil
+	}
+	if p.scanOptional(CLOSE_PARENTH) {
+		return call, nil
+	}
+
+	args, err := p.parseExprList()
+	if err != nil {
+		return nil, err
+	}
+	call.Args = args
+	return call, nil
+}
+
+// parseExprList parses a comma-separated list of expressions up to and
+// including the closing parenthesis.
+func (p *Parser) parseExprList() ([]Expr, error) {
+	var list []Expr
+	for {
+		x, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, x)
+
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+		case COMMA:
+			continue
+		case CLOSE_PARENTH:
+			return list, nil
+		default:
+			return nil, fmt.Errorf("found %q, expected , or )", litr)
+		}
+	}
+}
+
+// parseArray parses the elements of ARRAY[...] after the opening bracket.
+func (p *Parser) parseArray() (*ArrayExpr, error) {
+	array := &ArrayExpr{}
+	if p.scanOptional(CLOSE_BRACKET) {
+		return array, nil
+	}
+	for {
+		x, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		array.Elems = append(array.Elems, x)
+
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+		case COMMA:
+			continue
+		case CLOSE_BRACKET:
+			return array, nil
+		default:
+			return nil, fmt.Errorf("found %q, expected , or ]", litr)
+		}
+	}
+}
+
+// parsePlaceholder converts a PARAM literal into a Placeholder, numbering ?
+// placeholders in the order they appear in the statement.
+func (p *Parser) parsePlaceholder(litr string) *Placeholder {
+	param := &Placeholder{Text: litr}
+	switch litr[0] {
+	case '?':
+		p.params++
+		param.Ordinal = p.params
+	case '$':
+		param.Ordinal, _ = strconv.Atoi(litr[1:])
+	case ':':
+		param.Name = litr[1:]
+	}
+	return param
+}
+
+// parseVariable converts a VARIABLE literal such as @@session.sql_mode into a Variable.
+func parseVariable(litr string) *Variable {
+	if !strings.HasPrefix(litr, "@@") {
+		return &Variable{Name: litr[1:]}
+	}
+
+	v := &Variable{Name: litr[2:], System: true}
+	if i := strings.Index(v.Name, "."); i > 0 {
+		switch scope := strings.ToUpper(v.Name[:i]); scope {
+		case "GLOBAL", "SESSION", "LOCAL", "PERSIST", "PERSIST_ONLY":
+			v.Scope, v.Name = scope, v.Name[i+1:]
+		}
+	}
+	return v
+}
+
+// Inspect traverses an expression in depth-first order, calling f for each
+// node. Children are not visited when f returns false.
+func Inspect(e Expr, f func(Expr) bool) {
+	if e == nil || !f(e) {
+		return
+	}
+
+	switch e := e.(type) {
+	case *UnaryExpr:
+		Inspect(e.X, f)
+	case *BinaryExpr:
+		Inspect(e.LHS, f)
+		Inspect(e.RHS, f)
+	case *ParenExpr:
+		Inspect(e.X, f)
+	case *TupleExpr:
+		for _, x := range e.Exprs {
+			Inspect(x, f)
+		}
+	case *Call:
+		for _, arg := range e.Args {
+			Inspect(arg, f)
+		}
+	case *CastExpr:
+		Inspect(e.X, f)
+	case *ArrayExpr:
+		for _, x := range e.Elems {
+			Inspect(x, f)
+		}
+	case *IsNullExpr:
+		Inspect(e.X, f)
+	case *InExpr:
+		Inspect(e.X, f)
+		for _, x := range e.List {
+			Inspect(x, f)
+		}
+	case *BetweenExpr:
+		Inspect(e.X, f)
+		Inspect(e.Low, f)
+		Inspect(e.High, f)
+	}
+}
+
+// statementExprs returns the top-level expressions of a statement in source order.
+func statementExprs(stmt Statement) []Expr {
+	var exprs []Expr
+	switch stmt := stmt.(type) {
+	case *SelectStatement:
+		exprs = append(exprs, stmt.Exprs...)
+		exprs = append(exprs, stmt.Where)
+	case *InsertStatement:
+		for _, row := range stmt.Rows {
+			exprs = append(exprs, row...)
+		}
+	case *UpdateStatement:
+		for _, set := range stmt.Set {
+			exprs = append(exprs, set.Value)
+		}
+		exprs = append(exprs, stmt.Where)
+	case *DeleteStatement:
+		exprs = append(exprs, stmt.Where)
+	case *SetStatement:
+		for _, assignment := range stmt.Assignments {
+			exprs = append(exprs, assignment.Value)
+		}
+	case *ShowStatement:
+		exprs = append(exprs, stmt.Where)
+	case *ExplainStatement:
+		exprs = statementExprs(stmt.Statement)
+	}
+	return exprs
+}
+
+// Placeholders returns the bind parameters of a parsed statement in the order
+// they appear, so callers can check the number of arguments before executing it.
+func Placeholders(stmt Statement) []*Placeholder {
+	var params []*Placeholder
+	for _, e := range statementExprs(stmt) {
+		Inspect(e, func(e Expr) bool {
+			if param, ok := e.(*Placeholder); ok {
+				params = append(params, param)
+			}
+			return true
+		})
+	}
+	return params
+}
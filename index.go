@@ -0,0 +1,159 @@
+package SQLParser
+
+import "fmt"
+
+/* CREATE INDEX and DROP INDEX statements */
+
+// CreateIndexStatement is
+// CREATE [UNIQUE|FULLTEXT|SPATIAL] INDEX [IF NOT EXISTS] [name] ON table (columns) [options] [WHERE expr].
+// Where is the predicate of a partial index.
+type CreateIndexStatement struct {
+	Table       string
+	Index       *Index
+	IfNotExists bool
+	Where       Expr
+}
+
+// DropIndexStatement is DROP INDEX [IF EXISTS] name [ON table]. Table is
+// empty when the index is dropped by name alone, as in PostgreSQL and SQLite.
+type DropIndexStatement struct {
+	Name     string
+	Table    string
+	IfExists bool
+}
+
+func (*CreateIndexStatement) stmt() {}
+func (*DropIndexStatement) stmt()   {}
+
+// parseCreateIndex parses what follows CREATE [UNIQUE|FULLTEXT|SPATIAL] INDEX.
+func (p *Parser) parseCreateIndex(kind string) (*CreateIndexStatement, error) {
+	stmt := &CreateIndexStatement{}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "CONCURRENTLY") {
+		p.unScan()
+	}
+
+	var err error
+	if stmt.IfNotExists, err = p.scanIfNotExists(); err != nil {
+		return nil, err
+	}
+
+	var name, using string
+	if tok, litr := p.scanIdent(); tok == IDENT {
+		name = litr
+	} else {
+		p.unScan()
+	}
+	if p.scanOptional(USING) {
+		if using, err = p.scanIndexType(); err != nil {
+			return nil, err
+		}
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != ON {
+		return nil, fmt.Errorf("found %q, expected ON", litr)
+	}
+	p.scanOptional(ONLY) // PostgreSQL's ON ONLY, not indexing partitions
+	if stmt.Table, err = p.scanTableName(); err != nil {
+		return nil, err
+	}
+
+	if stmt.Index, err = p.scanIndex(kind); err != nil {
+		return nil, err
+	}
+	if stmt.Index.Name != "" {
+		return nil, fmt.Errorf("found %q, expected (", stmt.Index.Name)
+	}
+	stmt.Index.Name = name
+	if stmt.Index.Using == "" {
+		stmt.Index.Using = using
+	}
+
+	if p.scanOptional(WHERE) {
+		if stmt.Where, err = p.ParseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	return stmt, nil
+}
+
+// parseDropIndex parses what follows DROP INDEX.
+func (p *Parser) parseDropIndex() (*DropIndexStatement, error) {
+	stmt := &DropIndexStatement{}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "CONCURRENTLY") {
+		p.unScan()
+	}
+
+	var err error
+	if stmt.IfExists, err = p.scanIfExists(); err != nil {
+		return nil, err
+	}
+
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected index name", litr)
+	}
+	stmt.Name = litr
+
+	if p.scanOptional(ON) {
+		if stmt.Table, err = p.scanTableName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.scanOptional(RESTRICT) {
+		p.scanOptional(CASCADE)
+	}
+
+	return stmt, nil
+}
+
+// createIndex adds the index of a CREATE INDEX statement to its table.
+func (s Schema) createIndex(stmt *CreateIndexStatement) error {
+	table := s[stmt.Table]
+	if table == nil {
+		return fmt.Errorf("cannot create index on %q, table does not exist", stmt.Table)
+	}
+
+	name := stmt.Index.Name
+	if name != "" && (table.UniqueKeys[name] != nil || table.Keys[name] != nil) {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("cannot create index %q, it already exists", name)
+	}
+	for _, column := range stmt.Index.Columns {
+		if column.Expr == nil && table.Columns[column.Name] == nil {
+			return fmt.Errorf("cannot create index on %q, column %q does not exist", stmt.Table, column.Name)
+		}
+	}
+
+	table.addIndex(stmt.Index)
+	return nil
+}
+
+// dropIndex removes the index of a DROP INDEX statement, looking it up in
+// every table when the statement does not name one.
+func (s Schema) dropIndex(stmt *DropIndexStatement) error {
+	tables := []string{stmt.Table}
+	if stmt.Table == "" {
+		tables = s.TableNames()
+	}
+
+	for _, name := range tables {
+		table := s[name]
+		if table == nil {
+			return fmt.Errorf("cannot drop index %q, table %q does not exist", stmt.Name, name)
+		}
+		if (stmt.Name == "PRIMARY" && table.PrimaryKey != nil) || table.UniqueKeys[stmt.Name] != nil || table.Keys[stmt.Name] != nil {
+			return table.dropIndex(stmt.Name)
+		}
+	}
+
+	if stmt.IfExists {
+		return nil
+	}
+	return fmt.Errorf("cannot drop index %q, it does not exist", stmt.Name)
+}
@@ -4,9 +4,18 @@ import "bufio"
 import "strings"
 import "bytes"
 import "io"
+import "strconv"
 
 type Scanner struct{
 	r*bufio.Reader
+	raw bytes.Buffer	//source text of the last token, or since record was called
+	recording bool
+	pos int				//byte offset of the next rune to read
+	last int			//size of the last rune read
+	delimiter string	//statement delimiter set by DELIMITER, empty for ;
+	version int			//server version executing versioned comments, 0 for none
+	versioned bool		//inside an executed versioned comment
+	dialect Dialect
 }
 
 //Let's declare tokens
@@ -19,6 +28,7 @@ const(
 	ANNOTATION			//2
 	WHITESPACE      	//3
 	STRING      		//4
+	HINT				//5, optimizer hint /*+ ... */
 						// and so on
 	//Literals
 	IDENT
@@ -30,6 +40,26 @@ const(
 	SEMI_COLON
 	OPEN_PARENTH
 	CLOSE_PARENTH
+	DOT
+	OPEN_BRACKET
+	CLOSE_BRACKET
+
+	//Operators
+	LT
+	GT
+	LTE
+	GTE
+	NOT_EQUAL
+	PLUS
+	MINUS
+	SLASH
+	PERCENT
+	ASSIGN
+	DOUBLE_COLON
+	VARIABLE
+	PARAM
+	BITSTRING
+	HEXSTRING
 
 	//Standard data types
 	SIZE 
@@ -43,6 +73,35 @@ const(
 	LONGTEXT
 	MEDIUMTEXT
 	VARCHAR
+	MEDIUMINT
+	INTEGER
+	DECIMAL
+	DEC
+	NUMERIC
+	FIXED
+	REAL
+	BOOL
+	BOOLEAN
+	CHAR
+	BINARY
+	VARBINARY
+	TINYTEXT
+	TEXT
+	TINYBLOB
+	BLOB
+	MEDIUMBLOB
+	LONGBLOB
+	ENUM
+	JSON
+	GEOMETRY
+	POINT
+	LINESTRING
+	POLYGON
+	MULTIPOINT
+	MULTILINESTRING
+	MULTIPOLYGON
+	GEOMETRYCOLLECTION
+	YEAR
 	DATE
 	TIME
 	DATETIME
@@ -82,12 +141,157 @@ const(
 	UPDATE
 	SET
 	WHERE
+
+	//Transaction control keywords
+	BEGIN
+	START
+	TRANSACTION
+	COMMIT
+	ROLLBACK
+	SAVEPOINT
+	RELEASE
+	WORK
+	TO
+	WITH
+	CONSISTENT
+	SNAPSHOT
+	READ
+	ONLY
+	ISOLATION
+	LEVEL
+	REPEATABLE
+	COMMITTED
+	UNCOMMITTED
+	SERIALIZABLE
+	GLOBAL
+	SESSION
+
+	//Utility statement keywords
+	USE
+	SHOW
+	DESCRIBE
+	DESC
+	EXPLAIN
+	NAMES
+	CHARACTER
+	COLLATE
+	LOCAL
+	PERSIST
+	PERSIST_ONLY
+	FULL
+
+	//Expression keywords
+	AND
+	OR
+	IS
+	IN
+	LIKE
+	BETWEEN
+	TRUE
+	FALSE
+
+	//Account management keywords
+	GRANT
+	REVOKE
+	ON
+	BY
+	ALL
+	OPTION
+	PRIVILEGES
+	IDENTIFIED
+	FUNCTION
+	PROCEDURE
+
+	//Table DDL keywords
+	TRUNCATE
+	RENAME
+	TEMPORARY
+	CASCADE
+	RESTRICT
+	ALTER
+
+	//Index keywords
+	INDEX
+	FULLTEXT
+	SPATIAL
+	ASC
+	USING
+
+	//Column attribute keywords
+	UNSIGNED
+	SIGNED
+	ZEROFILL
+	CHECK
 )
 
 var (
 eof = rune(0)
 )
 
+//unreserved holds the keywords of MySQL that may still be used as plain
+//identifiers, e.g. a column named `level` or `session`; other dialects add
+//to them.
+var unreserved = map[Tokens]bool{
+	BEGIN:              true,
+	START:              true,
+	TRANSACTION:        true,
+	COMMIT:             true,
+	ROLLBACK:           true,
+	SAVEPOINT:          true,
+	WORK:               true,
+	CONSISTENT:         true,
+	SNAPSHOT:           true,
+	ONLY:               true,
+	ISOLATION:          true,
+	LEVEL:              true,
+	REPEATABLE:         true,
+	COMMITTED:          true,
+	UNCOMMITTED:        true,
+	SERIALIZABLE:       true,
+	GLOBAL:             true,
+	SESSION:            true,
+	NAMES:              true,
+	LOCAL:              true,
+	PERSIST:            true,
+	PERSIST_ONLY:       true,
+	FULL:               true,
+	PRIVILEGES:         true,
+	IDENTIFIED:         true,
+	FUNCTION:           true,
+	TEMPORARY:          true,
+	BOOL:               true,
+	BOOLEAN:            true,
+	FIXED:              true,
+	TEXT:               true,
+	ENUM:               true,
+	JSON:               true,
+	GEOMETRY:           true,
+	POINT:              true,
+	LINESTRING:         true,
+	POLYGON:            true,
+	MULTIPOINT:         true,
+	MULTILINESTRING:    true,
+	MULTIPOLYGON:       true,
+	GEOMETRYCOLLECTION: true,
+	YEAR:               true,
+	BIT:                true,
+	DATE:               true,
+	TIME:               true,
+	DATETIME:           true,
+	TIMESTAMP:          true,
+}
+
+//isKeyword reports whether tok is a keyword; keywords are declared after SIZE.
+func isKeyword(tok Tokens) bool {
+	return tok > SIZE
+}
+
+//isOperator reports whether tok is an operator; operators are declared from LT
+//to DOUBLE_COLON.
+func isOperator(tok Tokens) bool {
+	return tok >= LT && tok <= DOUBLE_COLON
+}
+
 func isDigit(ch rune) bool {
 	return (ch >='0' && ch<='9')
 }
@@ -96,6 +300,10 @@ func isString(ch rune) bool {
 	return ch=='\''
 }
 
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
 func isLetter(ch rune) bool {
 	return ((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z'))
 }
@@ -104,32 +312,121 @@ func isWhiteSpace(ch rune) bool {
 	return (ch == ' ' || ch == '\t' || ch == '\n')
 }
 
-//Create new scanner
-func NewScanner(r io.Reader) *Scanner {
-	return &Scanner{r: bufio.NewReader(r)}
+//Create new scanner reading MySQL unless an option sets another dialect
+func NewScanner(r io.Reader, opts ...Option) *Scanner {
+	scan := &Scanner{r: bufio.NewReader(r), dialect: MySQL}
+	for _, opt := range opts{
+		opt(scan)
+	}
+	return scan
 }
 
 func (scan *Scanner) unread(){
-	_ = scan.r.UnreadRune()
+	if scan.r.UnreadRune() == nil{
+		scan.pos -= scan.last
+		scan.raw.Truncate(scan.raw.Len()-scan.last)
+	}
+}
+
+//record starts keeping the source text read from now on; keep also keeps
+//the text of the token scanned last
+func (scan *Scanner) record(keep bool){
+	if !keep{
+		scan.raw.Reset()
+	}
+	scan.recording = true
+}
+
+//recorded stops recording and returns the source text read since record
+func (scan *Scanner) recorded() string{
+	scan.recording = false
+	return scan.raw.String()
+}
+
+//blank replaces the last n bytes of the source text with spaces, so the
+//delimiters of executed versioned comments are not recorded while the
+//recorded text keeps the offsets of the input
+func (scan *Scanner) blank(n int){
+	scan.raw.Truncate(scan.raw.Len()-n)
+	scan.raw.WriteString(strings.Repeat(" ", n))
+}
+
+//scanDelimiter reads the argument of a DELIMITER command, which runs to the
+//next whitespace, and makes it the statement delimiter
+func (scan *Scanner) scanDelimiter() string{
+	ch := scan.read()
+	for ch == ' ' || ch == '\t'{
+		ch = scan.read()
+	}
+
+	var buf bytes.Buffer
+	for ch != eof && !isWhiteSpace(ch) && ch != '\r'{
+		buf.WriteRune(ch)
+		ch = scan.read()
+	}
+	scan.unread()
+
+	scan.delimiter = buf.String()
+	if scan.delimiter == ";"{
+		scan.delimiter = ""
+	}
+	return buf.String()
+}
+
+//peek returns the ASCII character i bytes ahead without consuming anything
+func (scan *Scanner) peek(i int) rune{
+	b, err := scan.r.Peek(i+1)
+
+	if err!=nil {
+		return eof
+	}
+	return rune(b[i])
 }
 
 func (scan *Scanner) read() rune{
-	ch, _, err := scan.r.ReadRune()
+	ch, size, err := scan.r.ReadRune()
 
 	if err!=nil {
 		return eof
 	}
+	scan.raw.WriteRune(ch)
+	scan.pos += size
+	scan.last = size
 	return ch
 }
 
 //This function is used to capture the digits in the expression. 
 func (scan *Scanner) captureDigit() (tok Tokens, litr string) {
 	var buf bytes.Buffer
-	buf.WriteRune(scan.read())
+	first := scan.read()
+
+	//hexadecimal literal 0x0A0B
+	if first == '0' && (scan.peek(0) == 'x' || scan.peek(0) == 'X') && isHexDigit(scan.peek(1)){
+		scan.read()
+		for ch := scan.read(); ch != eof; ch = scan.read(){
+			if !isHexDigit(ch){
+				scan.unread()
+				break
+			}
+			buf.WriteRune(ch)
+		}
+		return HEXSTRING, buf.String()
+	}
+
+	buf.WriteRune(first)
+	dot := false
 
 	for{ 
 		if ch := scan.read(); ch==eof {
 			break
+		}else if ch == '.' && !dot {
+			dot = true
+			buf.WriteRune(ch)
+		}else if (ch == 'e' || ch == 'E') && (isDigit(scan.peek(0)) || (scan.peek(0) == '-' || scan.peek(0) == '+') && isDigit(scan.peek(1))){
+			//exponent of 1e-05
+			buf.WriteRune(ch)
+			buf.WriteRune(scan.read())
+			dot = true
 		}else if !isDigit(ch) {
 			scan.unread()
 			break
@@ -160,21 +457,130 @@ func (scan *Scanner) captureWhiteSpace() (tok Tokens, litr string) {
 	return WHITESPACE, buf.String()
 }
 
-//This function will scan comments
+//This function will scan comments once their /* has been read: hints /*+ ... */
+//are returned as HINT and versioned comments /*!NNNNN ... */ may be executed
 func (scan *Scanner) scanComments() (tok Tokens, litr string){
+	switch scan.read(){
+		case '!':
+			var version bytes.Buffer
+			ch := scan.read()
+			for ; isDigit(ch); ch = scan.read(){
+				version.WriteRune(ch)
+			}
+			scan.unread()
 
-	for{
-		if ch:= scan.read(); ch==eof {
-			return ILLEGAL, ""
-		}else if ch=='*'{
-			if c:= scan.read(); c=='/'{
-				break
+			//execute the content as MySQL of the configured version would
+			if v, _ := strconv.Atoi(version.String()); scan.version != 0 && v <= scan.version{
+				scan.versioned = true
+				scan.blank(len("/*!")+version.Len())
+				return WHITESPACE, " "
 			}
-		}
+
+		case '+':
+			body, ok := scan.scanCommentBody()
+			if !ok{
+				return ILLEGAL, ""
+			}
+			return HINT, strings.TrimSpace(body)
+
+		default:
+			scan.unread()
 	}
 
+	if _, ok := scan.scanCommentBody(); !ok{
+		return ILLEGAL, ""
+	}
 	return ANNOTATION, ""
+}
+
+//Read the rest of a comment up to and including */, reports false when the
+//comment is not terminated
+func (scan *Scanner) scanCommentBody() (string, bool){
+	var buf bytes.Buffer
+	for{
+		ch := scan.read()
+		if ch == eof{
+			return buf.String(), false
+		}
+		if ch == '*' && scan.peek(0) == '/'{
+			scan.read()
+			return buf.String(), true
+		}
+		buf.WriteRune(ch)
+	}
+}
+
+//SetDialect sets the dialect of the input, MySQL by default, see WithDialect
+func (scan *Scanner) SetDialect(dialect Dialect){
+	scan.dialect = dialect
+}
+
+//SetServerVersion makes the scanner execute the content of the versioned
+//comments, /*!NNNNN ... */, whose version is at most version, as a MySQL
+//server of that version does; it is written like in the comments, e.g. 80036
+//for 8.0.36. Other versioned comments, and all of them while the version is
+//0, are skipped like plain comments.
+func (scan *Scanner) SetServerVersion(version int){
+	scan.version = version
+}
+
+//This function will scan a user (@name) or system (@@scope.name) variable.
+func (scan *Scanner) scanVariable() (tok Tokens, litr string) {
+	var buf bytes.Buffer
+	buf.WriteRune(scan.read())
+
+	ch := scan.read()
+	if ch == '@' {
+		buf.WriteRune(ch)
+		ch = scan.read()
+	}
+
+	if ch == '\'' || ch == '`' || ch == '"' {
+		for c := scan.read(); c != ch && c != eof; c = scan.read() {
+			buf.WriteRune(c)
+		}
+		return VARIABLE, buf.String()
+	}
+
+	for ; isLetter(ch) || isDigit(ch) || ch == '_' || ch == '.' || ch == '$'; ch = scan.read() {
+		buf.WriteRune(ch)
+	}
+	scan.unread()
+
+	if ch := buf.String(); ch == "@" || ch == "@@" {
+		return ILLEGAL, ch
+	}
+	return VARIABLE, buf.String()
+}
+
+//This function will scan a $1 or :name placeholder whose prefix has been read.
+func (scan *Scanner) scanParam(prefix rune) (tok Tokens, litr string) {
+	var buf bytes.Buffer
+	buf.WriteRune(prefix)
+
+	for{
+		if ch := scan.read(); ch==eof {
+			break
+		}else if !isLetter(ch) && !isDigit(ch) && ch != '_' {
+			scan.unread()
+			break
+		}else{
+			buf.WriteRune(ch)
+		}
+	}
+
+	return PARAM, buf.String()
+}
 
+//escapes maps the character after a backslash in a string to its value;
+//other escaped characters stand for themselves
+var escapes = map[rune]rune{
+	'0': 0,
+	'b': '\b',
+	'n': '\n',
+	'r': '\r',
+	't': '\t',
+	'Z': 26,
 }
 
 //This function will scan a string. 
@@ -182,29 +588,276 @@ func (scan *Scanner) scanString() (tok Tokens, litr string) {
 	var buf bytes.Buffer
 	ch := scan.read()
 
-	readStr := func(c rune) {
+	//a doubled quote stands for the quote itself; strings also accept
+	//backslash escapes. An unterminated string is ILLEGAL.
+	readStr := func(c rune, escape bool) bool {
 		for {
-			if ch := scan.read(); ch==c {
-				break
-			} else {
-				_, _ = buf.WriteRune(ch)
+			ch := scan.read()
+			switch {
+			case ch == eof:
+				return false
+			case ch == c:
+				if scan.peek(0) != c {
+					return true
+				}
+				scan.read()
+			case ch == '\\' && escape:
+				ch = scan.read()
+				if ch == eof {
+					return false
+				}
+				if e, ok := escapes[ch]; ok {
+					ch = e
+				} else if ch == '%' || ch == '_' {
+					//kept escaped for LIKE patterns
+					buf.WriteRune('\\')
+				}
 			}
+			_, _ = buf.WriteRune(ch)
 		}
 	}
 
-	switch ch {
-	case '`':
+	//the dialect tells the quotes of identifiers and the strings with
+	//backslash escapes, such as PostgreSQL's E'...'
+	escape := scan.dialect.StringEscapes(0)
+	if ch == 'e' || ch == 'E' {
+		escape = true
+		ch = scan.read()
+	}
+
+	switch quote := scan.dialect.IdentQuote(ch); {
+	case quote != 0:
 		tok = IDENT
-		readStr('`')
-	case '\'':
+		if !readStr(quote, false) {
+			return ILLEGAL, string(ch) + buf.String()
+		}
+	case ch == '\'' || ch == '"':
 		tok = STRING
-		readStr('\'')
+		if !readStr(ch, escape) {
+			return ILLEGAL, string(ch) + buf.String()
+		}
 	default:
 		return ILLEGAL, string(ch)
 	}
 	return tok, buf.String()
 }
 
+//scanDollarString lexes a PostgreSQL $$...$$ or $tag$...$tag$ string, the
+//first $ has been read
+func (scan *Scanner) scanDollarString() (tok Tokens, litr string){
+	tag := "$"
+	for ch := scan.read(); ch != '$'; ch = scan.read(){
+		if !isLetter(ch) && !isDigit(ch) && ch != '_'{
+			return ILLEGAL, tag + string(ch)
+		}
+		tag += string(ch)
+	}
+	tag += "$"
+
+	var buf bytes.Buffer
+	for{
+		ch := scan.read()
+		if ch == eof{
+			return ILLEGAL, tag + buf.String()
+		}
+		buf.WriteRune(ch)
+		if ch == '$' && bytes.HasSuffix(buf.Bytes(), []byte(tag)){
+			return STRING, strings.TrimSuffix(buf.String(), tag)
+		}
+	}
+}
+
+//scanHexString lexes the digits of a X'0A0B' literal, the X' has been read
+func (scan *Scanner) scanHexString() (tok Tokens, litr string){
+	var buf bytes.Buffer
+
+	for{
+		ch := scan.read()
+		if ch == '\''{
+			return HEXSTRING, buf.String()
+		}else if !isHexDigit(ch){
+			return ILLEGAL, "X'" + buf.String() + string(ch)
+		}
+		buf.WriteRune(ch)
+	}
+}
+
+//scanBitString lexes the digits of a b'0101' literal, the b' has been read
+func (scan *Scanner) scanBitString() (tok Tokens, litr string){
+	var buf bytes.Buffer
+
+	for{
+		ch := scan.read()
+		if ch == '\''{
+			return BITSTRING, buf.String()
+		}else if ch != '0' && ch != '1'{
+			return ILLEGAL, "b'" + buf.String() + string(ch)
+		}
+		buf.WriteRune(ch)
+	}
+}
+
+//keywords maps the upper-cased keywords of the built-in dialects to their tokens
+var keywords = map[string]Tokens{
+	"DROP":               DROP,
+	"IF":                 IF,
+	"EXISTS":             EXISTS,
+	"LOCK":               LOCK,
+	"UNLOCK":             UNLOCK,
+	"TABLES":             TABLES,
+	"WRITE":              WRITE,
+	"CREATE":             CREATE,
+	"TABLE":              TABLE,
+	"NOT":                NOT,
+	"NULL":               NULL,
+	"DEFAULT":            DEFAULT,
+	"COMMENT":            COMMENT,
+	"KEY":                KEY,
+	"UNIQUE":             UNIQUE,
+	"CONSTRAINT":         CONSTRAINT,
+	"PRIMARY":            PRIMARY,
+	"FOREIGN":            FOREIGN,
+	"REFERENCES":         REFERENCES,
+	"AUTO_INCREMENT":     AUTO_INCREMENT,
+	"CURRENT_TIMESTAMP":  CURRENT_TIMESTAMP,
+	"BIT":                BIT,
+	"TINYINT":            TINYINT,
+	"SMALLINT":           SMALLINT,
+	"INT":                INT,
+	"BIGINT":             BIGINT,
+	"FLOAT":              FLOAT,
+	"DOUBLE":             DOUBLE,
+	"VARCHAR":            VARCHAR,
+	"LONGTEXT":           LONGTEXT,
+	"MEDIUMTEXT":         MEDIUMTEXT,
+	"MEDIUMINT":          MEDIUMINT,
+	"INTEGER":            INTEGER,
+	"DECIMAL":            DECIMAL,
+	"DEC":                DEC,
+	"NUMERIC":            NUMERIC,
+	"FIXED":              FIXED,
+	"REAL":               REAL,
+	"BOOL":               BOOL,
+	"BOOLEAN":            BOOLEAN,
+	"CHAR":               CHAR,
+	"BINARY":             BINARY,
+	"VARBINARY":          VARBINARY,
+	"TINYTEXT":           TINYTEXT,
+	"TEXT":               TEXT,
+	"TINYBLOB":           TINYBLOB,
+	"BLOB":               BLOB,
+	"MEDIUMBLOB":         MEDIUMBLOB,
+	"LONGBLOB":           LONGBLOB,
+	"ENUM":               ENUM,
+	"JSON":               JSON,
+	"GEOMETRY":           GEOMETRY,
+	"POINT":              POINT,
+	"LINESTRING":         LINESTRING,
+	"POLYGON":            POLYGON,
+	"MULTIPOINT":         MULTIPOINT,
+	"MULTILINESTRING":    MULTILINESTRING,
+	"MULTIPOLYGON":       MULTIPOLYGON,
+	"GEOMETRYCOLLECTION": GEOMETRYCOLLECTION,
+	"YEAR":               YEAR,
+	"DATE":               DATE,
+	"TIME":               TIME,
+	"DATETIME":           DATETIME,
+	"TIMESTAMP":          TIMESTAMP,
+
+	//SQL Queries
+	"SELECT":             SELECT,
+	"FROM":               FROM,
+	"INTO":               INTO,
+	"VALUES":             VALUES,
+	"INSERT":             INSERT,
+	"DELETE":             DELETE,
+	"UPDATE":             UPDATE,
+	"SET":                SET,
+	"WHERE":              WHERE,
+
+	//Transaction control
+	"BEGIN":              BEGIN,
+	"START":              START,
+	"TRANSACTION":        TRANSACTION,
+	"COMMIT":             COMMIT,
+	"ROLLBACK":           ROLLBACK,
+	"SAVEPOINT":          SAVEPOINT,
+	"RELEASE":            RELEASE,
+	"WORK":               WORK,
+	"TO":                 TO,
+	"WITH":               WITH,
+	"CONSISTENT":         CONSISTENT,
+	"SNAPSHOT":           SNAPSHOT,
+	"READ":               READ,
+	"ONLY":               ONLY,
+	"ISOLATION":          ISOLATION,
+	"LEVEL":              LEVEL,
+	"REPEATABLE":         REPEATABLE,
+	"COMMITTED":          COMMITTED,
+	"UNCOMMITTED":        UNCOMMITTED,
+	"SERIALIZABLE":       SERIALIZABLE,
+	"GLOBAL":             GLOBAL,
+	"SESSION":            SESSION,
+
+	//Utility statement
+	"USE":                USE,
+	"SHOW":               SHOW,
+	"DESCRIBE":           DESCRIBE,
+	"DESC":               DESC,
+	"EXPLAIN":            EXPLAIN,
+	"NAMES":              NAMES,
+	"CHARACTER":          CHARACTER,
+	"COLLATE":            COLLATE,
+	"LOCAL":              LOCAL,
+	"PERSIST":            PERSIST,
+	"PERSIST_ONLY":       PERSIST_ONLY,
+	"FULL":               FULL,
+
+	//Expression
+	"AND":                AND,
+	"OR":                 OR,
+	"IS":                 IS,
+	"IN":                 IN,
+	"LIKE":               LIKE,
+	"BETWEEN":            BETWEEN,
+	"TRUE":               TRUE,
+	"FALSE":              FALSE,
+
+	//Account management
+	"GRANT":              GRANT,
+	"REVOKE":             REVOKE,
+	"ON":                 ON,
+	"BY":                 BY,
+	"ALL":                ALL,
+	"OPTION":             OPTION,
+	"PRIVILEGES":         PRIVILEGES,
+	"IDENTIFIED":         IDENTIFIED,
+	"FUNCTION":           FUNCTION,
+	"PROCEDURE":          PROCEDURE,
+
+	//Table DDL
+	"TRUNCATE":           TRUNCATE,
+	"RENAME":             RENAME,
+	"TEMPORARY":          TEMPORARY,
+	"CASCADE":            CASCADE,
+	"RESTRICT":           RESTRICT,
+	"ALTER":              ALTER,
+
+	//Index
+	"INDEX":              INDEX,
+	"FULLTEXT":           FULLTEXT,
+	"SPATIAL":            SPATIAL,
+	"ASC":                ASC,
+	"USING":              USING,
+
+	//Column attribute
+	"UNSIGNED":           UNSIGNED,
+	"SIGNED":             SIGNED,
+	"ZEROFILL":           ZEROFILL,
+	"CHECK":              CHECK,
+}
+
+//scanSQLKeyWords scans a word, which is a keyword of the dialect or an identifier
 func (scan *Scanner) scanSQLKeyWords() (tok Tokens, litr string){
 	var buf bytes.Buffer
 
@@ -221,127 +874,73 @@ func (scan *Scanner) scanSQLKeyWords() (tok Tokens, litr string){
 		}
 	}
 
-	switch strings.ToUpper(buf.String()){
-
-		case "DROP":
-			return DROP, buf.String()
-		case "IF":
-			return IF, buf.String()
-		case "EXISTS":
-			return EXISTS, buf.String()
-		case "LOCK":
-			return LOCK, buf.String()
-		case "UNLOCK":
-			return UNLOCK, buf.String()
-		case "TABLES":
-			return TABLES, buf.String()
-		case "WRITE":
-			return WRITE, buf.String()
-		case "CREATE":
-			return CREATE, buf.String()
-		case "TABLE":
-			return TABLE, buf.String()
-		case "NOT":
-			return NOT, buf.String()
-		case "NULL":
-			return NULL, buf.String()
-		case "DEFAULT":
-			return DEFAULT, buf.String()
-		case "COMMENT":
-			return COMMENT, buf.String()
-		case "KEY":
-			return KEY, buf.String()
-		case "UNIQUE":
-			return UNIQUE, buf.String()
-		case "CONSTRAINT":
-			return CONSTRAINT, buf.String()
-		case "PRIMARY":
-			return PRIMARY, buf.String()
-		case "FOREIGN":
-			return FOREIGN, buf.String()
-		case "REFERENCES":
-			return REFERENCES, buf.String()
-		case "AUTO_INCREMENT":
-			return AUTO_INCREMENT, buf.String()
-		case "CURRENT_TIMESTAMP":
-			return CURRENT_TIMESTAMP, buf.String()
-		case "BIT":
-			return BIT, buf.String()
-		case "TINYINT":
-			return TINYINT, buf.String()
-		case "SMALLINT":
-			return SMALLINT, buf.String()
-		case "INT":
-			return INT, buf.String()
-		case "BIGINT":
-			return BIGINT, buf.String()
-		case "FLOAT":
-			return FLOAT, buf.String()
-		case "DOUBLE":
-			return DOUBLE, buf.String()
-		case "VARCHAR":
-			return VARCHAR, buf.String()
-		case "LONGTEXT":
-			return LONGTEXT, buf.String()
-		case "MEDIUMTEXT":
-			return MEDIUMTEXT, buf.String()
-		case "DATE":
-			return DATE, buf.String()
-		case "TIME":
-			return TIME, buf.String()
-		case "DATETIME":
-			return DATETIME, buf.String()
-		case "TIMESTAMP":
-			return TIMESTAMP, buf.String()
-
-		//SQL Queries cases 
-		case "SELECT":
-			return SELECT, buf.String()
-		case "FROM":
-			return FROM, buf.String()
-		case "INTO":
-			return INTO, buf.String()
-		case "VALUES":
-			return VALUES, buf.String()
-		case "INSERT":
-			return INSERT, buf.String()
-		case "DELETE":
-			return DELETE, buf.String()
-		case "UPDATE":
-			return UPDATE, buf.String()
-		case "SET":
-			return SET, buf.String()
-		case "WHERE":
-			return WHERE, buf.String()
-
-		default:
-		return IDENT, buf.String()
-	}	
+	if tok, ok := scan.dialect.Keyword(strings.ToUpper(buf.String())); ok{
+		return tok, buf.String()
+	}
+	return IDENT, buf.String()
 }
 
+//Scan returns the next token and its literal; an operator the dialect does
+//not have is ILLEGAL
 func (scan *Scanner) Scan() (tok Tokens, litr string) {
+	tok, litr = scan.scanToken()
+	if isOperator(tok) && !scan.dialect.Operator(tok){
+		return ILLEGAL, litr
+	}
+	return tok, litr
+}
+
+func (scan *Scanner) scanToken() (tok Tokens, litr string) {
+	if !scan.recording{
+		scan.raw.Reset()
+	}
+
+	//a delimiter set by DELIMITER ends statements like ; does
+	if scan.delimiter != ""{
+		if b, err := scan.r.Peek(len(scan.delimiter)); err == nil && string(b) == scan.delimiter{
+			for n := 0; n < len(scan.delimiter); n += scan.last{
+				scan.read()
+			}
+			return SEMI_COLON, scan.delimiter
+		}
+	}
+
 	ch := scan.read()
 
 	if isWhiteSpace(ch){
 		scan.unread()
 		return scan.captureWhiteSpace()
-	}else if isLetter(ch){
+	}else if isLetter(ch) || ch == '_'{
 		scan.unread()
+		if (ch == 'b' || ch == 'B') && scan.peek(1) == '\''{
+			scan.read()
+			scan.read()
+			return scan.scanBitString()
+		}
+		if (ch == 'x' || ch == 'X') && scan.peek(1) == '\''{
+			scan.read()
+			scan.read()
+			return scan.scanHexString()
+		}
+		if (ch == 'e' || ch == 'E') && scan.peek(1) == '\'' && scan.dialect.StringEscapes('E'){
+			return scan.scanString()
+		}
 		return scan.scanSQLKeyWords()
 	} else if isDigit(ch) {
 		scan.unread()
 		return scan.captureDigit()
-	} else if ch == '\'' || ch == '`' {
+	} else if ch == '\'' || ch == '"' || scan.dialect.IdentQuote(ch) != 0 {
 		scan.unread()
 		return scan.scanString()
 	} else if ch == '/' {
 		if c := scan.read(); c == '*' {
-			scan.unread()
-			scan.unread()
 			return scan.scanComments()
 		}
 		scan.unread()
-		return ILLEGAL, string(ch)
+		return SLASH, string(ch)
+	} else if ch == '@' {
+		scan.unread()
+		return scan.scanVariable()
 	}
 
 	switch ch {
@@ -353,6 +952,13 @@ func (scan *Scanner) Scan() (tok Tokens, litr string) {
 		return COMMA, ","
 
 	case '*':
+		//the end of an executed versioned comment
+		if scan.versioned && scan.peek(0) == '/'{
+			scan.read()
+			scan.versioned = false
+			scan.blank(len("*/"))
+			return WHITESPACE, " "
+		}
 		return ASTERISK, "*"
 
 	case '(':
@@ -361,21 +967,87 @@ func (scan *Scanner) Scan() (tok Tokens, litr string) {
 	case ')':
 		return CLOSE_PARENTH, ")"
 
+	case '.':
+		return DOT, "."
+
+	case '[':
+		return OPEN_BRACKET, "["
+
+	case ']':
+		return CLOSE_BRACKET, "]"
+
 	case ';':
 		return SEMI_COLON, ";"
 
 	case '=':
 		return EQUAL, "="
 
+	case '+':
+		return PLUS, "+"
+
+	case '%':
+		return PERCENT, "%"
+
+	case '<':
+		switch scan.read() {
+		case '=':
+			return LTE, "<="
+		case '>':
+			return NOT_EQUAL, "<>"
+		}
+		scan.unread()
+		return LT, "<"
+
+	case '>':
+		if c := scan.read(); c == '=' {
+			return GTE, ">="
+		}
+		scan.unread()
+		return GT, ">"
+
+	case '!':
+		if c := scan.read(); c == '=' {
+			return NOT_EQUAL, "!="
+		}
+		scan.unread()
+		return ILLEGAL, string(ch)
+
+	case ':':
+		if c := scan.read(); c == '=' {
+			return ASSIGN, ":="
+		} else if c == ':' {
+			return DOUBLE_COLON, "::"
+		} else if isLetter(c) || c == '_' {
+			scan.unread()
+			return scan.scanParam(ch)
+		}
+		scan.unread()
+		return ILLEGAL, string(ch)
+
+	case '$':
+		if c := scan.read(); isDigit(c) {
+			scan.unread()
+			return scan.scanParam(ch)
+		}else if (c == '$' || isLetter(c) || c == '_') && scan.grammar().DollarQuotes(){
+			scan.unread()
+			return scan.scanDollarString()
+		}
+		scan.unread()
+		return ILLEGAL, string(ch)
+
+	case '?':
+		return PARAM, "?"
+
 	case '-':
 		if c := scan.read(); c == '-' { 
 			for {
-				if c := scan.read(); c == '\n' {
+				if c := scan.read(); c == '\n' || c == eof {
 					return ANNOTATION, ""
 				}
 			}
 		}
-		return ILLEGAL, string(ch)
+		scan.unread()
+		return MINUS, "-"
 		
 	default:
 		return ILLEGAL, string(ch)
@@ -0,0 +1,72 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_Comment_Lexer(t *testing.T){
+
+	var tests = []struct{
+		s string
+		version int
+		tokens []Tokens
+		literals map[int]string
+	}{
+		//plain comments, including ones closed by **/ or starting with /*/
+		{s: "/*/ a */x/* b **/", tokens: []Tokens{ANNOTATION, IDENT, ANNOTATION}},
+		{s: "a/b", tokens: []Tokens{IDENT, SLASH, IDENT}},
+		{s: "/* open", tokens: []Tokens{ILLEGAL}},
+
+		//hints
+		{s: "SELECT /*+ NO_RANGE_OPTIMIZATION(t) */ *", tokens: []Tokens{SELECT, WHITESPACE, HINT, WHITESPACE, ASTERISK},
+			literals: map[int]string{2: "NO_RANGE_OPTIMIZATION(t)"}},
+
+		//versioned comments are skipped without a server version
+		{s: "/*!40101 SET x=1 */;", tokens: []Tokens{ANNOTATION, SEMI_COLON}},
+
+		//and executed when the version is high enough
+		{s: "/*!40101 SET x=1*/;", version: 80036,
+			tokens: []Tokens{WHITESPACE, WHITESPACE, SET, WHITESPACE, IDENT, EQUAL, SIZE, WHITESPACE, SEMI_COLON}},
+		{s: "/*!90000 SET x=1 */;", version: 80036, tokens: []Tokens{ANNOTATION, SEMI_COLON}},
+		{s: "/*! SET */", version: 50700, tokens: []Tokens{WHITESPACE, WHITESPACE, SET, WHITESPACE, WHITESPACE}},
+	}
+
+	for i, tt := range tests{
+		fmt.Printf("%q\n", tt.s)
+
+		scan := NewScanner(strings.NewReader(tt.s))
+		scan.SetServerVersion(tt.version)
+
+		var tokens []Tokens
+		var literals []string
+		for{
+			tok, litr := scan.Scan()
+			if tok == EOF{
+				break
+			}
+			fmt.Printf("%v: %v\n", tok, litr)
+			tokens = append(tokens, tok)
+			literals = append(literals, litr)
+			if tok == ILLEGAL{
+				break
+			}
+		}
+
+		if len(tokens) != len(tt.tokens){
+			t.Errorf("%d. %q: tokens mismatch! expected %v but found %v", i, tt.s, tt.tokens, tokens)
+			continue
+		}
+		for j := range tokens{
+			if tokens[j] != tt.tokens[j]{
+				t.Errorf("%d. %q: expected: %v found: %v", i, tt.s, tt.tokens[j], tokens[j])
+			}
+		}
+		for j, litr := range tt.literals{
+			if literals[j] != litr{
+				t.Errorf("%d. %q: expected: %q found: %q", i, tt.s, litr, literals[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_Placeholder_Lexer(t *testing.T){
+
+	sqlStmt_q := "SELECT * FROM user WHERE id=? AND name=:user_name AND age>$12"
+
+	fmt.Printf("%q\n", sqlStmt_q)
+
+	scan := NewScanner(strings.NewReader(sqlStmt_q))
+
+	listOfTokens := []Tokens{
+		SELECT, WHITESPACE, ASTERISK, WHITESPACE, FROM, WHITESPACE, IDENT, WHITESPACE, WHERE, WHITESPACE,
+		IDENT, EQUAL, PARAM, WHITESPACE, AND, WHITESPACE, IDENT, EQUAL, PARAM, WHITESPACE, AND, WHITESPACE,
+		IDENT, GT, PARAM,
+	}
+	listOfLiterals := map[int]string{12: "?", 18: ":user_name", 24: "$12"}
+
+	var tokens []Tokens
+	var literals []string
+
+	for{
+		if tok, litr :=scan.Scan(); tok!=EOF{
+			fmt.Printf("%v: %v\n", tok, litr)
+			tokens=append(tokens, tok)
+			literals=append(literals, litr)
+		}else{
+			break
+		}
+	}
+
+	if len(tokens)!=len(listOfTokens){
+		t.Fatalf("Tokens Mismatch! expected %d but found %d\n", len(listOfTokens), len(tokens))
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i] != listOfTokens[i] {
+			t.Errorf("expected: %v found: %v", listOfTokens[i], tokens[i])
+		}
+	}
+
+	for i, litr := range listOfLiterals {
+		if literals[i] != litr {
+			t.Errorf("expected: %q found: %q", litr, literals[i])
+		}
+	}
+
+}
@@ -0,0 +1,117 @@
+package SQLParser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_String_Lexer(t *testing.T){
+
+	sqlStmt_q := "'it''s' \"say \\\"hi\\\"\" 'a\\tb\\\\c\\%' `odd``name` b'101' 'open"
+
+	fmt.Printf("%q\n", sqlStmt_q)
+
+	scan := NewScanner(strings.NewReader(sqlStmt_q))
+
+	listOfTokens := []Tokens{
+		STRING, WHITESPACE, STRING, WHITESPACE, STRING, WHITESPACE, IDENT, WHITESPACE, BITSTRING, WHITESPACE, ILLEGAL,
+	}
+	listOfLiterals := map[int]string{0: "it's", 2: "say \"hi\"", 4: "a\tb\\c\\%", 6: "odd`name", 8: "101", 10: "'open"}
+
+	var tokens []Tokens
+	var literals []string
+
+	for{
+		if tok, litr :=scan.Scan(); tok!=EOF{
+			fmt.Printf("%v: %v\n", tok, litr)
+			tokens=append(tokens, tok)
+			literals=append(literals, litr)
+		}else{
+			break
+		}
+	}
+
+	if len(tokens)!=len(listOfTokens){
+		t.Fatalf("Tokens Mismatch! expected %d but found %d\n", len(listOfTokens), len(tokens))
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i] != listOfTokens[i] {
+			t.Errorf("expected: %v found: %v", listOfTokens[i], tokens[i])
+		}
+	}
+
+	for i, litr := range listOfLiterals {
+		if literals[i] != litr {
+			t.Errorf("expected: %q found: %q", litr, literals[i])
+		}
+	}
+
+}
+
+func Test_Postgres_String_Lexer(t *testing.T){
+
+	sqlStmt_q := `"Order" 'a\b' E'a\tb' $$it's$$ $fn$ $$ $fn$ x::text[]`
+
+	scan := NewScanner(strings.NewReader(sqlStmt_q))
+	scan.SetDialect(PostgreSQL)
+
+	listOfTokens := []Tokens{
+		IDENT, WHITESPACE, STRING, WHITESPACE, STRING, WHITESPACE, STRING, WHITESPACE, STRING, WHITESPACE,
+		IDENT, DOUBLE_COLON, TEXT, OPEN_BRACKET, CLOSE_BRACKET,
+	}
+	listOfLiterals := map[int]string{0: "Order", 2: "a\\b", 4: "a\tb", 6: "it's", 8: " $$ "}
+
+	var tokens []Tokens
+	var literals []string
+	for{
+		tok, litr := scan.Scan()
+		if tok == EOF{
+			break
+		}
+		tokens=append(tokens, tok)
+		literals=append(literals, litr)
+	}
+
+	if !reflect.DeepEqual(tokens, listOfTokens){
+		t.Fatalf("Tokens Mismatch! expected %v but found %v\n", listOfTokens, tokens)
+	}
+	for i, litr := range listOfLiterals {
+		if literals[i] != litr {
+			t.Errorf("expected: %q found: %q", litr, literals[i])
+		}
+	}
+}
+
+func Test_SQLite_String_Lexer(t *testing.T){
+
+	sqlStmt_q := `"Order" [display name] 'a\b' E'x'`
+
+	scan := NewScanner(strings.NewReader(sqlStmt_q))
+	scan.SetDialect(SQLite)
+
+	listOfTokens := []Tokens{IDENT, WHITESPACE, IDENT, WHITESPACE, STRING, WHITESPACE, IDENT, STRING}
+	listOfLiterals := map[int]string{0: "Order", 2: "display name", 4: "a\\b", 7: "x"}
+
+	var tokens []Tokens
+	var literals []string
+	for{
+		tok, litr := scan.Scan()
+		if tok == EOF{
+			break
+		}
+		tokens=append(tokens, tok)
+		literals=append(literals, litr)
+	}
+
+	if !reflect.DeepEqual(tokens, listOfTokens){
+		t.Fatalf("Tokens Mismatch! expected %v but found %v\n", listOfTokens, tokens)
+	}
+	for i, litr := range listOfLiterals {
+		if literals[i] != litr {
+			t.Errorf("expected: %q found: %q", litr, literals[i])
+		}
+	}
+}
@@ -3,73 +3,235 @@ package SQLParser
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 type Column struct{
 	Name string
 	Type string 
-	Size int 
-	Default interface{}
+	Size int //length of string and binary types, display width of integer types
+	Precision int //of DECIMAL, FLOAT and DOUBLE
+	Scale int
+	Fsp int //fractional seconds precision of TIME, DATETIME and TIMESTAMP
+	Members []string //values of ENUM and SET
+	Unsigned bool
+	Zerofill bool
+	Charset string //effective character set of character columns
+	Collation string //effective collation, empty for the charset's default
+	Default interface{} //an Expr: a literal, CURRENT_TIMESTAMP or a parenthesized expression
+	OnUpdate Expr //ON UPDATE CURRENT_TIMESTAMP
+	Generated Expr //expression of a generated column
+	Stored bool //STORED rather than VIRTUAL generated column
 	Comment string
 	Nullable bool
-	AutoIncr bool
+	AutoIncr bool //AUTO_INCREMENT, also set for serial and identity columns
+	Invisible bool
+	Identity string //"ALWAYS" or "BY DEFAULT" for GENERATED ... AS IDENTITY
+	TimeZone bool //TIME and TIMESTAMP WITH TIME ZONE
+	Dimensions int //of an array type such as text[]
+	TypeName string //declared type of a column of a user-defined enum
+	Affinity string //SQLite type affinity: "INTEGER", "TEXT", "BLOB", "REAL" or "NUMERIC"
+	OnConflict string //SQLite ON CONFLICT resolution of NOT NULL, upper-cased
 }
 
+//Constraint is a FOREIGN KEY constraint. ForeignKey and ColumnName hold the
+//first column of each side; Columns and RefColumns hold all of them
 type Constraint struct{
 	Index string 
 	ForeignKey string 
 	TableName string 
 	ColumnName string 
+	Columns []string
+	RefCatalog string //catalog of the referenced table, if qualified
+	RefSchema string //database of the referenced table, if qualified or after USE
+	RefColumns []string //nil for REFERENCES table until the Schema finds its primary key
+	Match string //"FULL", "PARTIAL", "SIMPLE" or empty
+	OnDelete string //"CASCADE", "SET NULL", "SET DEFAULT", "RESTRICT", "NO ACTION" or empty
+	OnUpdate string
+	Deferrable bool
+	InitiallyDeferred bool
 }
 
-type Table struct{
+//CheckConstraint is a CHECK (expr) constraint of a table or column
+type CheckConstraint struct{
+	Name string
+	Expr Expr
+	Enforced bool
+}
+
+//Index is a PRIMARY KEY, UNIQUE, KEY, FULLTEXT or SPATIAL index
+type Index struct{
 	Name string
+	Kind string //"PRIMARY", "UNIQUE", "KEY", "FULLTEXT" or "SPATIAL"
+	Columns []*IndexColumn
+	Using string //"BTREE", "HASH" or empty
+	Comment string
+	KeyBlockSize int
+	Parser string //WITH PARSER of a FULLTEXT index
+	Invisible bool
+	OnConflict string //SQLite ON CONFLICT resolution of a primary or unique key, upper-cased
+}
+
+//IndexColumn is an indexed column, or a prefix of it when Length is set.
+//Expr is set instead of Name for a functional key part such as ((a + b))
+type IndexColumn struct{
+	Name string
+	Length int
+	Desc bool
+	Expr Expr
+	autoIncr bool //SQLite PRIMARY KEY (column AUTOINCREMENT), set on the column by addIndex
+}
+
+//Table holds the columns, indexes and constraints of a table both by name
+//and, in the List slices, in declaration order
+type Table struct{
+	Name string //the name without its database
+	Catalog string
+	Database string //from the qualified name, or the USE before CREATE TABLE
 	Columns map[string]*Column
-	PrimaryKey string 
-	UniqueKeys map[string]string
-	Keys map[string]string
+	PrimaryKey *Index
+	UniqueKeys map[string]*Index
+	Keys map[string]*Index //KEY, FULLTEXT and SPATIAL indexes
 	Constraints map[string]*Constraint
-	Extras map[string]string
+	Checks map[string]*CheckConstraint
+	Extras map[string]string //every table option, keyed by its lower-cased name
+	Engine string
+	Charset string //DEFAULT CHARSET of the table
+	Collation string
+	AutoIncrement int64
+	RowFormat string //upper-cased, e.g. "DYNAMIC"
+	Comment string
+	KeyBlockSize int
+	Temporary bool
+	WithoutRowID bool //SQLite WITHOUT ROWID
+	Strict bool //SQLite STRICT
+	AsSelect *SelectStatement //query of CREATE TABLE ... AS SELECT
+	Partitioning *Partitioning
+	View *View //set when the table is a view
+	Triggers []*Trigger //in the order they fire
+	ColumnList []*Column
+	IndexList []*Index //every index, the primary key included
+	ConstraintList []*Constraint
+	CheckList []*CheckConstraint
 }
 
 //schema is used to store table details
 type Schema map[string]*Table
 
+//TableNames returns the names of the schema's tables in sorted order
+func (s Schema) TableNames() []string{
+	names := make([]string, 0, len(s))
+	for name := range s{
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//Tables returns the schema's tables sorted by name
+func (s Schema) Tables() []*Table{
+	tables := make([]*Table, 0, len(s))
+	for _, name := range s.TableNames(){
+		tables = append(tables, s[name])
+	}
+	return tables
+}
+
 //Parser
 type Parser struct{
 	sc *Scanner 
+	params int //number of ? placeholders in the current statement
+	database string //database of the last USE seen by Parse or a DumpReader, qualifying table names
+	types map[string][]string //labels of the enum types declared by CREATE TYPE
 	buf struct{
 		tok Tokens
 		litr string
+		pos int //byte offset of the token
 		n int
 	}
 }
 
-//Type stores SQL datatype tokens and their literal representation
+//mysqlTypes maps the lower-cased names of the MySQL types to the type of a
+//column
+var mysqlTypes = map[string]string{
+	"bit":                "bit",
+	"tinyint":            "tinyint",
+	"smallint":           "smallint",
+	"int":                "int",
+	"bigint":             "bigint",
+	"float":              "float",
+	"double":             "double",
+	"varchar":            "varchar",
+	"longtext":           "longtext",
+	"mediumtext":         "mediumtext",
+	"mediumint":          "mediumint",
+	"integer":            "int",
+	"decimal":            "decimal",
+	"dec":                "decimal",
+	"numeric":            "decimal",
+	"fixed":              "decimal",
+	"real":               "double",
+	"bool":               "boolean",
+	"boolean":            "boolean",
+	"char":               "char",
+	"binary":             "binary",
+	"varbinary":          "varbinary",
+	"tinytext":           "tinytext",
+	"text":               "text",
+	"tinyblob":           "tinyblob",
+	"blob":               "blob",
+	"mediumblob":         "mediumblob",
+	"longblob":           "longblob",
+	"enum":               "enum",
+	"set":                "set",
+	"json":               "json",
+	"geometry":           "geometry",
+	"point":              "point",
+	"linestring":         "linestring",
+	"polygon":            "polygon",
+	"multipoint":         "multipoint",
+	"multilinestring":    "multilinestring",
+	"multipolygon":       "multipolygon",
+	"geometrycollection": "geometrycollection",
+	"year":               "year",
+	"date":               "date",
+	"time":               "time",
+	"datetime":           "datetime",
+	"timestamp":          "timestamp",
+}
+
+//Type stores SQL datatype tokens and their literal representation in MySQL,
+//the types of the MySQL dialect.
+//
+//Deprecated: dialects name their types by Dialect.Type, e.g. MySQL.Type.
 var Type map[Tokens]string
 
 func init(){
 	Type = make(map[Tokens]string)
-	Type[BIT]="bit"
-	Type[TINYINT] = "tinyint"
-	Type[SMALLINT] = "smallint"
-	Type[INT] = "int"
-	Type[BIGINT] = "bigint"
-	Type[FLOAT] = "float"
-	Type[DOUBLE] = "double"
-	Type[VARCHAR] = "varchar"
-	Type[LONGTEXT] = "longtext"
-	Type[MEDIUMTEXT] = "mediumtext"
-	Type[DATE] = "date"
-	Type[TIME] = "time"
-	Type[DATETIME] = "datetime"
-	Type[TIMESTAMP] = "timestamp"
-}
-
-// NewParser returns a new parser for given reader
-func NewParser(r io.Reader) *Parser {
-	return &Parser{sc: NewScanner(r)}
+	for name, tok := range keywords{
+		if typ, ok := MySQL.Type(strings.ToLower(name)); ok{
+			Type[tok] = typ
+		}
+	}
+}
+
+// NewParser returns a new parser for given reader, configured by the options
+// of its Scanner
+func NewParser(r io.Reader, opts ...Option) *Parser {
+	return &Parser{sc: NewScanner(r, opts...)}
+}
+
+//SetDialect sets the dialect of the input, MySQL by default, see WithDialect
+func (p *Parser) SetDialect(dialect Dialect){
+	p.sc.SetDialect(dialect)
+}
+
+//SetServerVersion sets the MySQL version executing versioned comments,
+//see Scanner.SetServerVersion
+func (p *Parser) SetServerVersion(version int){
+	p.sc.SetServerVersion(version)
 }
 
 func (p *Parser) scan()(tok Tokens, litr string){
@@ -79,8 +241,9 @@ func (p *Parser) scan()(tok Tokens, litr string){
 		return p.buf.tok, p.buf.litr 
 	}
 
+	pos := p.sc.pos
 	tok, litr = p.sc.Scan()
-	p.buf.tok, p.buf.litr = tok, litr
+	p.buf.tok, p.buf.litr, p.buf.pos = tok, litr, pos
 	return 
 }
 
@@ -88,9 +251,20 @@ func (p *Parser) unScan(){
 	p.buf.n=1
 }
 
+//record starts keeping the source text from the next token on, which may be
+//the unscanned one, and returns its byte offset
+func (p *Parser) record() int{
+	if p.buf.n!=0{
+		p.sc.record(true)
+		return p.buf.pos
+	}
+	p.sc.record(false)
+	return p.sc.pos
+}
+
 func (p *Parser) scanIgnoreWhiteSpace() (tok Tokens, litr string) {
 	tok, litr = p.scan()
-	if tok==WHITESPACE || tok == ANNOTATION {
+	for tok==WHITESPACE || tok == ANNOTATION || tok == HINT {
 		tok, litr = p.scan()
 	}
 	return
@@ -99,61 +273,304 @@ func (p *Parser) scanIgnoreWhiteSpace() (tok Tokens, litr string) {
 func (p *Parser) scanIdent()(tok Tokens, litr string){
 	tok, litr = p.scanIgnoreWhiteSpace()
 
-	if tok!= IDENT{
+	if tok!= IDENT && !p.sc.nonReserved(tok){
 		return ILLEGAL, litr
 	}
 
-	return tok, litr
+	return IDENT, litr
+}
+
+// scanOptional consumes the next token if it is tok and reports whether it did.
+func (p *Parser) scanOptional(tok Tokens) bool {
+	if t, _ := p.scanIgnoreWhiteSpace(); t == tok {
+		return true
+	}
+	p.unScan()
+	return false
+}
+
+// scanIfExists consumes an optional IF EXISTS and reports whether it was present.
+func (p *Parser) scanIfExists() (bool, error) {
+	if !p.scanOptional(IF) {
+		return false, nil
+	}
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != EXISTS {
+		return false, fmt.Errorf("found %q, expected EXISTS", litr)
+	}
+	return true, nil
+}
+
+// scanIfNotExists consumes an optional IF NOT EXISTS and reports whether it was present.
+func (p *Parser) scanIfNotExists() (bool, error) {
+	if !p.scanOptional(IF) {
+		return false, nil
+	}
+	tok1, litr1 := p.scanIgnoreWhiteSpace()
+	tok2, litr2 := p.scanIgnoreWhiteSpace()
+	if tok1 != NOT || tok2 != EXISTS {
+		return false, fmt.Errorf("found %q, expected NOT EXISTS", litr1+" "+litr2)
+	}
+	return true, nil
+}
+
+// isWord reports whether an identifier token spells the given non-keyword word.
+func isWord(tok Tokens, litr, word string) bool {
+	return tok == IDENT && strings.EqualFold(litr, word)
+}
+
+//Scan a data type and its (size), (precision, scale), (fsp) or ('member', ...)
+//arguments into the column; named types may be followed by WITH TIME ZONE
+//and array dimensions, declared types are any names or none
+func (p *Parser) scanType(column *Column) error{
+	syntax := p.sc.grammar().TypeSyntax()
+	if syntax == DeclaredTypes{
+		return p.scanSQLiteType(column)
+	}
+	if err := p.scanBaseType(column); err != nil{
+		return err
+	}
+	if syntax == NamedTypes{
+		return p.scanTypeSuffix(column)
+	}
+	return nil
+}
+
+//Scan a data type and its arguments without the suffixes of scanType; the
+//dialect names the type of the column
+func (p *Parser) scanBaseType(column *Column) error{
+	tok, litr := p.scanIgnoreWhiteSpace()
+	name := strings.ToLower(litr)
+
+	switch{
+		case tok == CHARACTER:
+			tok, name = CHAR, "char"
+			if tok1, litr1 := p.scanIgnoreWhiteSpace(); isWord(tok1, litr1, "VARYING"){
+				tok, name = VARCHAR, "varchar"
+			}else{
+				p.unScan()
+			}
+		case tok == DOUBLE:
+			if tok1, litr1 := p.scanIgnoreWhiteSpace(); !isWord(tok1, litr1, "PRECISION"){
+				p.unScan()
+			}
+		case tok == SET:
+		case tok == IDENT && p.sc.grammar().TypeSyntax() == NamedTypes:
+			return p.scanPostgresType(litr, column)
+		case tok != IDENT && (tok < BIT || tok > TIMESTAMP):
+			return fmt.Errorf("found %q, expected type", litr)
+	}
+	typ, ok := p.sc.dialect.Type(name)
+	if !ok{
+		return fmt.Errorf("found %q, expected type", litr)
+	}
+	column.Type = typ
+
+	tok1, litr1 := p.scanIgnoreWhiteSpace()
+	if tok1!=OPEN_PARENTH{
+		p.unScan()
+		return nil
+	}
+
+	switch tok{
+		case ENUM, SET:
+			for{
+				tok2, litr2 := p.scanIgnoreWhiteSpace()
+				if tok2 != STRING{
+					return fmt.Errorf("found %q, expected 'value'", litr2)
+				}
+				column.Members = append(column.Members, litr2)
+
+				if tok3, litr3 := p.scanIgnoreWhiteSpace(); tok3 == CLOSE_PARENTH{
+					return nil
+				}else if tok3 != COMMA{
+					return fmt.Errorf("found %q, expected , or )", litr3)
+				}
+			}
+
+		case DECIMAL, DEC, NUMERIC, FIXED, FLOAT, DOUBLE, REAL:
+			tok2, litr2 := p.scanIgnoreWhiteSpace()
+			tok3, litr3 := p.scanIgnoreWhiteSpace()
+			if tok2 == SIZE && tok3 == COMMA{
+				tok4, litr4 := p.scanIgnoreWhiteSpace()
+				tok5, litr5 := p.scanIgnoreWhiteSpace()
+				if tok4 != SIZE || tok5 != CLOSE_PARENTH{
+					return fmt.Errorf("found %q, expected type(precision, scale)", litr+litr1+litr2+litr3+litr4+litr5)
+				}
+				column.Precision, _ = strconv.Atoi(litr2)
+				column.Scale, _ = strconv.Atoi(litr4)
+				return nil
+			}
+			if tok2 != SIZE || tok3 != CLOSE_PARENTH{
+				return fmt.Errorf("found %q, expected type(precision, scale)", litr+litr1+litr2+litr3)
+			}
+			column.Precision, _ = strconv.Atoi(litr2)
+			return nil
+	}
+
+	tok2, litr2 := p.scanIgnoreWhiteSpace()
+	tok3, litr3 := p.scanIgnoreWhiteSpace()
+
+	if tok2 != SIZE || tok3 != CLOSE_PARENTH{
+		return fmt.Errorf("found %q, expected type(integer)", litr+litr1+litr2+litr3)
+	}
+
+	size, _ := strconv.Atoi(litr2)
+	switch tok{
+		case TIME, DATETIME, TIMESTAMP:
+			column.Fsp = size
+		default:
+			column.Size = size
+	}
+	return nil
 }
 
-func (p *Parser) scanType()(string, int, error){
+//Scan DEFAULT followed by a literal, a function such as CURRENT_TIMESTAMP(6)
+//or a parenthesized expression
+func (p *Parser) scanDefault()(Expr, error){
 	tok, litr := p.scanIgnoreWhiteSpace()
 
-	if tok>=BIT && tok<=TIMESTAMP{
-		tok1, litr1 := p.scanIgnoreWhiteSpace()
+	if tok!=DEFAULT{
+		return nil, fmt.Errorf("found %q, expected DEFUALT", litr)
+	}
 
-		if tok1!=OPEN_PARENTH{
-			p.unScan()
-			return Type[tok], 0, nil
+	val, err := p.parseUnary()
+	if err != nil{
+		return nil, err
+	}
+
+	//fold a negative number into a single literal
+	if unary, ok := val.(*UnaryExpr); ok{
+		if number, ok := unary.X.(*NumberLit); ok{
+			if unary.Op == MINUS{
+				return &NumberLit{Value: "-" + number.Value}, nil
+			}
+			return number, nil
 		}
+	}
+	return val, nil
+}
 
+//Scan the name of a collation following COLLATE, which may be quoted as a
+//string like COLLATE 'utf8mb4_bin'
+func (p *Parser) scanCollation() (string, error){
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok != IDENT && tok != STRING && tok != BINARY && !p.sc.nonReserved(tok){
+		return "", fmt.Errorf("found %q, expected collation", litr)
+	}
+	return litr, nil
+}
+
+//Scan CHARACTER SET name or CHARSET name of a column
+func (p *Parser) scanColumnCharset(column *Column) error{
+	tok, litr := p.scanIgnoreWhiteSpace()
+
+	if tok == CHARACTER{
+		if tok1, litr1 := p.scanIgnoreWhiteSpace(); tok1 != SET{
+			return fmt.Errorf("found %q, expected CHARACTER SET", litr1)
+		}
+	}else if !isWord(tok, litr, "CHARSET"){
+		return fmt.Errorf("found %q, expected column constraint", litr)
+	}
+
+	tok1, litr1 := p.scanIgnoreWhiteSpace()
+	if tok1 != IDENT && tok1 != STRING && tok1 != BINARY && !p.sc.nonReserved(tok1){
+		return fmt.Errorf("found %q, expected character set", litr1)
+	}
+	column.Charset = litr1
+	return nil
+}
+
+//Scan [GENERATED ALWAYS] AS (expr) [VIRTUAL|STORED] or the identity
+//GENERATED {ALWAYS|BY DEFAULT} AS IDENTITY [(sequence options)]; tok is the
+//first word and has been read
+func (p *Parser) scanGenerated(tok Tokens, litr string, column *Column) error{
+	if isWord(tok, litr, "GENERATED"){
+		identity := "ALWAYS"
+		tok1, litr1 := p.scanIgnoreWhiteSpace()
+		if tok1 == BY{
+			if tok, litr := p.scanIgnoreWhiteSpace(); tok != DEFAULT{
+				return fmt.Errorf("found %q, expected GENERATED BY DEFAULT", litr)
+			}
+			tok1, litr1, identity = IDENT, "ALWAYS", "BY DEFAULT"
+		}
 		tok2, litr2 := p.scanIgnoreWhiteSpace()
-		tok3, litr3 := p.scanIgnoreWhiteSpace()
+		if !isWord(tok1, litr1, "ALWAYS") || !isWord(tok2, litr2, "AS"){
+			return fmt.Errorf("found %q, expected GENERATED ALWAYS AS", litr1+" "+litr2)
+		}
 
-		if tok2 != SIZE || tok3 != CLOSE_PARENTH{
-			return "", 0, fmt.Errorf("found %q, expected type(integer)", litr+litr1+litr2+litr3)
+		if tok, litr := p.scanIgnoreWhiteSpace(); isWord(tok, litr, "IDENTITY"){
+			column.Identity = identity
+			column.AutoIncr = true
+			return p.scanIdentityOptions()
+		}else if identity != "ALWAYS"{
+			return fmt.Errorf("found %q, expected IDENTITY", litr)
 		}
+		p.unScan()
+	}
 
-		size, _ := strconv.Atoi(litr2)
-		return Type[tok], size, nil
+	expr, err := p.scanParenthExpr()
+	if err != nil{
+		return err
 	}
+	column.Generated = expr
 
-	return "", 0, fmt.Errorf("found %q, expected type", litr)
+	if tok, litr := p.scanIgnoreWhiteSpace(); isWord(tok, litr, "STORED"){
+		column.Stored = true
+	}else if !isWord(tok, litr, "VIRTUAL"){
+		p.unScan()
+	}
+	return nil
 }
 
-func (p *Parser) scanDefault()(string, error){
-	tok, litr := p.scanIgnoreWhiteSpace()
+//Scan a parenthesized expression, returning the expression inside
+func (p *Parser) scanParenthExpr() (Expr, error){
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH{
+		return nil, fmt.Errorf("found %q, expected (", litr)
+	}
 
-	if tok!=DEFAULT{
-		return "", fmt.Errorf("found %q, expected DEFUALT", litr)
+	expr, err := p.ParseExpr()
+	if err != nil{
+		return nil, err
 	}
 
-	tok, litr = p.scanIgnoreWhiteSpace()
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH{
+		return nil, fmt.Errorf("found %q, expected )", litr)
+	}
+	return expr, nil
+}
 
-	switch tok{
-		case NULL: 
-			return "null", nil
-		case CURRENT_TIMESTAMP:
-			return "Current Timestamp", nil
-		case STRING:
-			return litr, nil 
+//Scan (expr) [ENFORCED] once CHECK has been read; NOT ENFORCED is left to
+//the caller as NOT may start NOT NULL in a column definition
+func (p *Parser) scanCheck() (*CheckConstraint, error){
+	expr, err := p.scanParenthExpr()
+	if err != nil{
+		return nil, err
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "ENFORCED"){
+		p.unScan()
 	}
+	return &CheckConstraint{Expr: expr, Enforced: true}, nil
+}
 
-	return "", fmt.Errorf("found %q, expected NULL or value", litr)
+//Scan the NOT ENFORCED that may follow a table CHECK constraint
+func (p *Parser) scanNotEnforced(check *CheckConstraint) error{
+	if !p.scanOptional(NOT){
+		return nil
+	}
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "ENFORCED"){
+		return fmt.Errorf("found %q, expected NOT ENFORCED", litr)
+	}
+	check.Enforced = false
+	return nil
 }
 
-func (p *Parser) scanColumn() (*Column, error){
+//Scan a column definition; inline PRIMARY KEY and UNIQUE attributes add
+//an index to the table
+func (p *Parser) scanColumn(table *Table) (*Column, error){
 	var column = &Column{}
+	var check *CheckConstraint //the column's last CHECK, for NOT ENFORCED
+	var constraint string //name given by CONSTRAINT to the next key, check or reference
 	tok, litr := p.scanIdent()
 
 	if tok!=IDENT{
@@ -161,12 +578,9 @@ func (p *Parser) scanColumn() (*Column, error){
 	}
 
 	column.Name = litr
-	t, s, err := p.scanType()
-	if err != nil {
+	if err := p.scanType(column); err != nil {
 		return nil, err
 	}
-	column.Type = t
-	column.Size = s
 
 	for{
 		tok, litr = p.scanIgnoreWhiteSpace()
@@ -180,7 +594,24 @@ func (p *Parser) scanColumn() (*Column, error){
 					return nil, err
 				}
 				column.Default=val
-				column.Nullable=val=="null"
+				//DEFAULT NULL makes the column nullable, other defaults keep
+				//an explicit NULL or NOT NULL
+				if _, ok := val.(*NullLit); ok{
+					column.Nullable = true
+				}
+				if isNextval(val){
+					column.AutoIncr = true
+				}
+
+			case ON:
+				if tok1, litr1 := p.scanIgnoreWhiteSpace(); tok1!=UPDATE{
+					return nil, fmt.Errorf("found %q, expected ON UPDATE", litr1)
+				}
+				val, err := p.parsePrimary()
+				if err!=nil{
+					return nil, err
+				}
+				column.OnUpdate=val
 
 			case NULL: 
 				column.Nullable=true; 
@@ -188,11 +619,59 @@ func (p *Parser) scanColumn() (*Column, error){
 			case NOT:
 				tok1, litr1 := p.scanIgnoreWhiteSpace()
 
+				if isWord(tok1, litr1, "ENFORCED") && check != nil{
+					check.Enforced = false
+					continue
+				}
+				if isWord(tok1, litr1, "DEFERRABLE"){
+					continue
+				}
 				if tok1!=NULL{
 					return nil, fmt.Errorf("found %q, expected NULL", litr1)
 				}
 				column.Nullable=false
 
+				var err error
+				if column.OnConflict, err = p.scanConflictClause(); err != nil{
+					return nil, err
+				}
+
+			case CHECK:
+				var err error
+				if check, err = p.scanCheck(); err != nil{
+					return nil, err
+				}
+				check.Name, constraint = constraint, ""
+				table.addCheck(check)
+
+			case CONSTRAINT:
+				tok1, litr1 := p.scanIdent()
+				if tok1 != IDENT{
+					return nil, fmt.Errorf("found %q, expected constraint name", litr1)
+				}
+				constraint = litr1
+
+			case IDENT:
+				//FIRST and AFTER position a column of ALTER TABLE
+				if isWord(tok, litr, "FIRST") || isWord(tok, litr, "AFTER"){
+					p.unScan()
+					return column, nil
+				}
+				if isWord(tok, litr, "VISIBLE") || isWord(tok, litr, "INVISIBLE"){
+					column.Invisible = isWord(tok, litr, "INVISIBLE")
+					continue
+				}
+				if !isWord(tok, litr, "GENERATED") && !isWord(tok, litr, "AS"){
+					p.unScan()
+					if err := p.scanColumnCharset(column); err != nil{
+						return nil, err
+					}
+					continue
+				}
+				if err := p.scanGenerated(tok, litr, column); err != nil{
+					return nil, err
+				}
+
 			case COMMENT:
 				if tok1, litr1 :=p.scanIgnoreWhiteSpace(); tok1==STRING{
 					column.Comment=litr1
@@ -203,12 +682,80 @@ func (p *Parser) scanColumn() (*Column, error){
 			case AUTO_INCREMENT:
 				column.AutoIncr = true
 
-			case COMMA, ASTERISK, CLOSE_PARENTH:
+			case UNSIGNED:
+				column.Unsigned = true
+
+			case SIGNED:
+				column.Unsigned = false
+
+			case ZEROFILL:
+				column.Zerofill = true
+				column.Unsigned = true
+
+			case CHARACTER:
 				p.unScan()
-				return column, nil
+				if err := p.scanColumnCharset(column); err != nil{
+					return nil, err
+				}
 
-			case EOF:
-				return nil, fmt.Errorf("Unexpected EOF")
+			case COLLATE:
+				collation, err := p.scanCollation()
+				if err != nil{
+					return nil, err
+				}
+				column.Collation = collation
+
+				//PostgreSQL collations may be qualified, e.g. pg_catalog."C"
+				for p.scanOptional(DOT){
+					tok1, litr1 := p.scanIdent()
+					if tok1 != IDENT{
+						return nil, fmt.Errorf("found %q, expected collation", litr1)
+					}
+					column.Collation += "." + litr1
+				}
+
+			case PRIMARY, KEY:
+				if tok == PRIMARY{
+					if tok1, litr1 := p.scanIgnoreWhiteSpace(); tok1!=KEY{
+						return nil, fmt.Errorf("found %q, expected PRIMARY KEY", litr1)
+					}
+				}
+				index := &Index{Name: p.primaryKeyName(constraint), Kind: "PRIMARY", Columns: []*IndexColumn{{Name: column.Name}}}
+				if p.sc.grammar().RowidTables(){
+					if p.scanOptional(DESC){
+						index.Columns[0].Desc = true
+					}else{
+						p.scanOptional(ASC)
+					}
+				}
+				var err error
+				if index.OnConflict, err = p.scanConflictClause(); err != nil{
+					return nil, err
+				}
+				table.addIndex(index)
+				constraint = ""
+
+			case UNIQUE:
+				p.scanOptional(KEY)
+				index := &Index{Name: constraint, Kind: "UNIQUE", Columns: []*IndexColumn{{Name: column.Name}}}
+				var err error
+				if index.OnConflict, err = p.scanConflictClause(); err != nil{
+					return nil, err
+				}
+				table.addIndex(index)
+				constraint = ""
+
+			case REFERENCES:
+				reference := &Constraint{Index: constraint, ForeignKey: column.Name, Columns: []string{column.Name}}
+				if err := p.scanReferences(reference); err != nil{
+					return nil, err
+				}
+				table.addConstraint(reference)
+				constraint = ""
+
+			case COMMA, ASTERISK, CLOSE_PARENTH, SEMI_COLON, EOF:
+				p.unScan()
+				return column, nil
 
 			default:
 				return nil, fmt.Errorf("found %q, expected column constraint", litr)
@@ -216,284 +763,888 @@ func (p *Parser) scanColumn() (*Column, error){
 	}
 }
 
-func (p *Parser) scanPrimarykey()(string, error){
-	tok1, litr1 := p.scanIgnoreWhiteSpace()
-	tok2, litr2 := p.scanIgnoreWhiteSpace()
+//Scan the parenthesized column list of an index: (col [(length)] [ASC|DESC], ...)
+func (p *Parser) scanIndexColumns() ([]*IndexColumn, error){
+	var columns []*IndexColumn
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH{
+		return nil, fmt.Errorf("found %q, expected (", litr)
+	}
 
-	if tok1!=PRIMARY || tok2!=KEY{
-		return "", fmt.Errorf("found %q, expected PRIMARY KEY", litr1+litr2)
+	for{
+		column, err := p.scanIndexColumn()
+		if err != nil{
+			return nil, err
+		}
+
+		//PostgreSQL and SQLite may collate an indexed column, e.g. name
+		//COLLATE NOCASE; the collation is not kept
+		if p.sc.grammar().IndexCollation() && p.scanOptional(COLLATE){
+			if tok, litr := p.scanIdent(); tok != IDENT && tok != STRING{
+				return nil, fmt.Errorf("found %q, expected collation", litr)
+			}
+		}
+		if p.scanOptional(DESC){
+			column.Desc = true
+		}else{
+			p.scanOptional(ASC)
+		}
+		if p.sc.grammar().RowidTables() && p.scanOptional(AUTO_INCREMENT){
+			column.autoIncr = true
+		}
+		if tok, litr := p.scanIgnoreWhiteSpace(); isWord(tok, litr, "NULLS"){
+			//PostgreSQL NULLS FIRST or NULLS LAST
+			if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "FIRST") && !isWord(tok, litr, "LAST"){
+				return nil, fmt.Errorf("found %q, expected NULLS FIRST or LAST", litr)
+			}
+		}else{
+			p.unScan()
+		}
+		columns = append(columns, column)
+
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok{
+			case COMMA:
+				continue
+			case CLOSE_PARENTH:
+				return columns, nil
+			default:
+				return nil, fmt.Errorf("found %q, expected , or )", litr)
+		}
 	}
+}
+
+//Scan one element of an index column list: a column and its (length), or
+//a functional key part ((expr)); dialects with index expressions also index
+//function calls and name an operator class after the element
+func (p *Parser) scanIndexColumn() (*IndexColumn, error){
+	tok, litr := p.scanIdent()
 
+	if tok == ILLEGAL && litr == "("{
+		expr, err := p.ParseExpr()
+		if err != nil{
+			return nil, err
+		}
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH{
+			return nil, fmt.Errorf("found %q, expected )", litr)
+		}
+		return &IndexColumn{Expr: expr}, nil
+	}
+	if tok != IDENT{
+		return nil, fmt.Errorf("found %q, expected ident", litr)
+	}
+	column := &IndexColumn{Name: litr}
+
+	if p.sc.grammar().IndexExpressions(){
+		if p.scanOptional(OPEN_PARENTH){
+			args, err := p.parseExprList()
+			if err != nil{
+				return nil, err
+			}
+			column = &IndexColumn{Expr: &Call{Name: strings.ToUpper(litr), Args: args}}
+		}
+		if tok, litr := p.scanIdent(); tok != IDENT || isWord(tok, litr, "NULLS"){
+			p.unScan()
+		}
+		return column, nil
+	}
+
+	if p.scanOptional(OPEN_PARENTH){
+		tok1, litr1 := p.scanIgnoreWhiteSpace()
+		tok2, litr2 := p.scanIgnoreWhiteSpace()
+		if tok1 != SIZE || tok2 != CLOSE_PARENTH{
+			return nil, fmt.Errorf("found %q, expected (integer)", litr1+litr2)
+		}
+		column.Length, _ = strconv.Atoi(litr1)
+	}
+	return column, nil
+}
+
+//Scan USING BTREE or USING HASH; dialects with index expressions have other
+//methods such as GIN
+func (p *Parser) scanIndexType() (string, error){
 	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok == IDENT && p.sc.grammar().IndexExpressions(){
+		return strings.ToUpper(litr), nil
+	}
+	if tok != IDENT || (!strings.EqualFold(litr, "BTREE") && !strings.EqualFold(litr, "HASH")){
+		return "", fmt.Errorf("found %q, expected BTREE or HASH", litr)
+	}
+	return strings.ToUpper(litr), nil
+}
+
+//Scan an index definition once its leading keywords have been read:
+//[name] [USING type] (columns) [options]
+func (p *Parser) scanIndex(kind string) (*Index, error){
+	index := &Index{Kind: kind}
 
-	if tok==OPEN_PARENTH{
+	if tok, litr := p.scanIdent(); tok == IDENT{
+		index.Name = litr
+	}else{
 		p.unScan()
-		tok, litr = p.scanParenthIdent()
+	}
+
+	var err error
+	if p.scanOptional(USING){
+		if index.Using, err = p.scanIndexType(); err != nil{
+			return nil, err
+		}
+	}
 
-		if tok!=IDENT{
-			return "", fmt.Errorf("found %q, expected ident", litr)
+	if index.Columns, err = p.scanIndexColumns(); err != nil{
+		return nil, err
+	}
+
+	//index options
+	for{
+		tok, litr := p.scanIgnoreWhiteSpace()
+
+		switch{
+			case tok == USING:
+				if index.Using, err = p.scanIndexType(); err != nil{
+					return nil, err
+				}
+
+			case tok == COMMENT:
+				tok1, litr1 := p.scanIgnoreWhiteSpace()
+				if tok1 != STRING{
+					return nil, fmt.Errorf("found %q, expected 'comment'", litr1)
+				}
+				index.Comment = litr1
+
+			case isWord(tok, litr, "KEY_BLOCK_SIZE"):
+				p.scanOptional(EQUAL)
+				tok1, litr1 := p.scanIgnoreWhiteSpace()
+				if tok1 != SIZE{
+					return nil, fmt.Errorf("found %q, expected integer", litr1)
+				}
+				index.KeyBlockSize, _ = strconv.Atoi(litr1)
+
+			case tok == WITH:
+				tok1, litr1 := p.scanIgnoreWhiteSpace()
+				tok2, litr2 := p.scanIdent()
+				if !isWord(tok1, litr1, "PARSER") || tok2 != IDENT{
+					return nil, fmt.Errorf("found %q, expected WITH PARSER name", litr1+" "+litr2)
+				}
+				index.Parser = litr2
+
+			case isWord(tok, litr, "VISIBLE"):
+				index.Invisible = false
+
+			case isWord(tok, litr, "INVISIBLE"):
+				index.Invisible = true
+
+			case tok == ON && p.sc.grammar().ConflictClauses():
+				p.unScan()
+				if index.OnConflict, err = p.scanConflictClause(); err != nil{
+					return nil, err
+				}
+
+			default:
+				p.unScan()
+				return index, nil
 		}
+	}
+}
 
-		return litr, nil
+//Return the name of a primary key named name, "" for none: MySQL names every
+//primary key PRIMARY, other dialects keep the name of its CONSTRAINT
+func (p *Parser) primaryKeyName(name string) string{
+	if !p.sc.grammar().NamedPrimaryKeys(){
+		return "PRIMARY"
 	}
+	return name
+}
 
-	tok, litr = p.scanIdent()
+//Scan an index definition whose first keyword tok has been read
+func (p *Parser) scanTableIndex(tok Tokens, litr string) (*Index, error){
+	switch tok{
+		case PRIMARY:
+			if tok1, litr1 := p.scanIgnoreWhiteSpace(); tok1!=KEY{
+				return nil, fmt.Errorf("found %q, expected PRIMARY KEY", litr+litr1)
+			}
+			index, err := p.scanIndex("PRIMARY")
+			if err != nil{
+				return nil, err
+			}
+			index.Name = p.primaryKeyName(index.Name)
+			return index, nil
 
-	if tok!=IDENT{
-		return "", fmt.Errorf("found %q, expected ident", litr)
+		case UNIQUE, FULLTEXT, SPATIAL:
+			if !p.scanOptional(KEY){
+				p.scanOptional(INDEX)
+			}
+			return p.scanIndex(strings.ToUpper(litr))
 	}
 
-	return litr, nil
+	return p.scanIndex("KEY")
+}
+
+//Name an unnamed index like MySQL does: after its first column, with a
+//_2, _3, ... suffix when that name is taken
+func (table *Table) indexName(index *Index) string{
+	base := index.Columns[0].Name
+	if index.Columns[0].Expr != nil{
+		base = "functional_index"
+	}
+	name := base
+	for i := 2; table.UniqueKeys[name] != nil || table.Keys[name] != nil; i++{
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	return name
 }
 
-func (p *Parser) scanParenthIdent() (Tokens, string){
+//isCharacterType reports whether columns of type t have a character set
+func isCharacterType(t string) bool{
+	switch t{
+		case "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "enum", "set":
+			return true
+	}
+	return false
+}
+
+//Resolve the effective character set and collation of character columns
+//from their own attributes and the table's DEFAULT CHARSET and COLLATE
+func (table *Table) resolveCharsets(){
+	charset, collation := table.Charset, table.Collation
+	//the collation name starts with its character set
+	if charset == "" && collation != ""{
+		charset = strings.SplitN(collation, "_", 2)[0]
+	}
+
+	for _, column := range table.ColumnList{
+		if !isCharacterType(column.Type){
+			continue
+		}
+		if column.Charset == "" && column.Collation != ""{
+			column.Charset = strings.SplitN(column.Collation, "_", 2)[0]
+		}
+		if column.Charset == ""{
+			column.Charset = charset
+			column.Collation = collation
+		}
+	}
+}
+
+//Add a column to the table, replacing any column of the same name
+func (table *Table) addColumn(column *Column){
+	if table.Columns[column.Name] != nil{
+		for i, c := range table.ColumnList{
+			if c.Name == column.Name{
+				table.ColumnList[i] = column
+			}
+		}
+	}else{
+		table.ColumnList = append(table.ColumnList, column)
+	}
+	table.Columns[column.Name] = column
+}
+
+//Add an index to the table, naming it if needed
+func (table *Table) addIndex(index *Index){
+	table.IndexList = append(table.IndexList, index)
+
+	switch index.Kind{
+		case "PRIMARY":
+			if index.Name == ""{
+				index.Name = "PRIMARY"
+			}
+			table.PrimaryKey = index
+			for _, column := range index.Columns{
+				if column.autoIncr && table.Columns[column.Name] != nil{
+					table.Columns[column.Name].AutoIncr = true
+				}
+			}
+		case "UNIQUE":
+			if index.Name == ""{
+				index.Name = table.indexName(index)
+			}
+			table.UniqueKeys[index.Name] = index
+		default:
+			if index.Name == ""{
+				index.Name = table.indexName(index)
+			}
+			table.Keys[index.Name] = index
+	}
+}
+
+//Scan CONSTRAINT [name] followed by a PRIMARY KEY, UNIQUE, FOREIGN KEY or
+//CHECK definition, adding it to the table
+func (p *Parser) scanConstraint(table *Table) error{
 	tok, litr := p.scanIgnoreWhiteSpace()
 
-	if tok!=OPEN_PARENTH{
-		return ILLEGAL, litr
+	if tok!=CONSTRAINT{
+		return fmt.Errorf("found %q, expected CONSTRAINT", litr)
 	}
 
+	name := ""
+	if tok, litr = p.scanIdent(); tok==IDENT{
+		name = litr
+	}else{
+		p.unScan()
+	}
 	tok, litr = p.scanIgnoreWhiteSpace()
 
-	if tok==IDENT{
-		tok1, litr1 := p.scanIgnoreWhiteSpace()
-		if tok1!=CLOSE_PARENTH{
-			return ILLEGAL, litr+litr1
+	switch tok{
+		case PRIMARY, UNIQUE:
+			index, err := p.scanTableIndex(tok, litr)
+			if err != nil{
+				return err
+			}
+			if index.Name == ""{
+				index.Name = name
+			}
+			table.addIndex(index)
+
+		case FOREIGN:
+			constraint, err := p.scanForeignKey()
+			if err != nil{
+				return err
+			}
+			if name != ""{
+				constraint.Index = name
+			}
+			table.addConstraint(constraint)
+
+		case CHECK:
+			check, err := p.scanCheck()
+			if err != nil{
+				return err
+			}
+			if err := p.scanNotEnforced(check); err != nil{
+				return err
+			}
+			check.Name = name
+			table.addCheck(check)
+
+		default:
+			return fmt.Errorf("found %q, expected PRIMARY KEY, UNIQUE, FOREIGN KEY or CHECK", litr)
+	}
+
+	return nil
+}
+
+//Scan KEY [name] (columns) REFERENCES ... once FOREIGN has been read
+func (p *Parser) scanForeignKey() (*Constraint, error){
+	constraint := &Constraint{}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != KEY {
+		return nil, fmt.Errorf("found %q, expected FOREIGN KEY", "FOREIGN"+litr)
+	}
+
+	//an index name may follow FOREIGN KEY
+	if tok, litr := p.scanIdent(); tok==IDENT{
+		constraint.Index = litr
+	}else{
+		p.unScan()
+	}
+
+	columns, err := p.scanIndexColumns()
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range columns{
+		constraint.Columns = append(constraint.Columns, column.Name)
+	}
+	constraint.ForeignKey = constraint.Columns[0]
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != REFERENCES{
+		return nil, fmt.Errorf("found %q, expected REFERENCES", litr)
+	}
+
+	if err := p.scanReferences(constraint); err != nil{
+		return nil, err
+	}
+
+	return constraint, nil
+}
+
+//Scan the reference definition following REFERENCES:
+//[[catalog.]schema.]table (columns) [MATCH type] [ON DELETE action] [ON UPDATE action]
+//[DEFERRABLE] [INITIALLY {DEFERRED|IMMEDIATE}]
+func (p *Parser) scanReferences(constraint *Constraint) error{
+	name, err := p.scanQualifiedName("`table_name`")
+	if err != nil {
+		return err
+	}
+	if name.Schema == ""{
+		name.Schema = p.database
+	}
+	constraint.RefCatalog, constraint.RefSchema, constraint.TableName = name.Catalog, name.Schema, name.Name
+
+	//without columns the primary key of the referenced table is referenced
+	if tok, _ := p.scanIgnoreWhiteSpace(); tok == OPEN_PARENTH{
+		p.unScan()
+		columns, err := p.scanIndexColumns()
+		if err != nil {
+			return err
+		}
+		for _, column := range columns{
+			constraint.RefColumns = append(constraint.RefColumns, column.Name)
+		}
+		constraint.ColumnName = constraint.RefColumns[0]
+	}else{
+		p.unScan()
+	}
+
+	for{
+		tok, litr := p.scanIgnoreWhiteSpace()
+
+		switch{
+			case isWord(tok, litr, "MATCH"):
+				tok1, litr1 := p.scanIgnoreWhiteSpace()
+				if tok1 != FULL && !isWord(tok1, litr1, "PARTIAL") && !isWord(tok1, litr1, "SIMPLE"){
+					return fmt.Errorf("found %q, expected FULL, PARTIAL or SIMPLE", litr1)
+				}
+				constraint.Match = strings.ToUpper(litr1)
+
+			case tok == ON:
+				event, litr1 := p.scanIgnoreWhiteSpace()
+				if event != DELETE && event != UPDATE{
+					return fmt.Errorf("found %q, expected ON DELETE or ON UPDATE", litr1)
+				}
+				action, err := p.scanReferenceAction()
+				if err != nil{
+					return err
+				}
+				if event == DELETE{
+					constraint.OnDelete = action
+				}else{
+					constraint.OnUpdate = action
+				}
+
+			case isWord(tok, litr, "DEFERRABLE"):
+				constraint.Deferrable = true
+
+			case isWord(tok, litr, "INITIALLY"):
+				tok1, litr1 := p.scanIgnoreWhiteSpace()
+				if !isWord(tok1, litr1, "DEFERRED") && !isWord(tok1, litr1, "IMMEDIATE"){
+					return fmt.Errorf("found %q, expected DEFERRED or IMMEDIATE", litr1)
+				}
+				constraint.InitiallyDeferred = isWord(tok1, litr1, "DEFERRED")
+
+			default:
+				p.unScan()
+				return nil
 		}
+	}
+}
 
-		return tok, litr
+//Scan a referential action: CASCADE, SET NULL, SET DEFAULT, RESTRICT or NO ACTION
+func (p *Parser) scanReferenceAction() (string, error){
+	tok, litr := p.scanIgnoreWhiteSpace()
+
+	switch{
+		case tok == CASCADE:
+			return "CASCADE", nil
+		case tok == RESTRICT:
+			return "RESTRICT", nil
+		case tok == SET:
+			switch tok1, _ := p.scanIgnoreWhiteSpace(); tok1{
+				case NULL:
+					return "SET NULL", nil
+				case DEFAULT:
+					return "SET DEFAULT", nil
+			}
+		case isWord(tok, litr, "NO"):
+			if tok1, litr1 := p.scanIgnoreWhiteSpace(); isWord(tok1, litr1, "ACTION"){
+				return "NO ACTION", nil
+			}
 	}
 
-	return ILLEGAL, ""
+	return "", fmt.Errorf("found %q, expected CASCADE, SET NULL, SET DEFAULT, RESTRICT or NO ACTION", litr)
 }
 
-func (p *Parser) scanKey() (string, string, error){
-	var index, column string
-	tok, litr := p.scanIgnoreWhiteSpace()
-
-	if tok!=KEY {
-		return "", "", fmt.Errorf("found %q, expected KEY", litr)
+//Add a check constraint to the table; unnamed ones are named like MySQL
+//does, table_chk_1, table_chk_2, ...
+func (table *Table) addCheck(check *CheckConstraint){
+	if check.Name == ""{
+		for i := 1; check.Name == "" || table.Checks[check.Name] != nil; i++{
+			check.Name = fmt.Sprintf("%s_chk_%d", table.Name, i)
+		}
 	}
+	table.Checks[check.Name] = check
+	table.CheckList = append(table.CheckList, check)
+}
 
-	//parse index 
-	tok, litr = p.scanIgnoreWhiteSpace()
-	if tok==IDENT{
-		index=litr
-	}else{
-		return "", "", fmt.Errorf("found %q, expected index", litr)
+//Add a foreign key to the table; unnamed ones are named like MySQL does,
+//table_ibfk_1, table_ibfk_2, ...
+func (table *Table) addConstraint(constraint *Constraint){
+	if constraint.Index == ""{
+		for i := 1; constraint.Index == "" || table.Constraints[constraint.Index] != nil; i++{
+			constraint.Index = fmt.Sprintf("%s_ibfk_%d", table.Name, i)
+		}
 	}
+	table.Constraints[constraint.Index] = constraint
+	table.ConstraintList = append(table.ConstraintList, constraint)
+}
 
-	//parse column 
-	tok, litr = p.scanIgnoreWhiteSpace()
+//Scan the table options following the column definitions into the typed
+//fields and Extras, up to the end of the statement or a PARTITION or AS
+//SELECT clause
+func (p *Parser) scanTableOptions(table *Table) error{
+	for{
+		tok, litr := p.scanIgnoreWhiteSpace()
 
-	if tok==IDENT{
-		column=litr
-	}else if tok==OPEN_PARENTH{
+		switch{
+			case tok == COMMA:
+				continue
+			case isWord(tok, litr, "WITHOUT") && p.sc.grammar().RowidTables():
+				if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "ROWID"){
+					return fmt.Errorf("found %q, expected WITHOUT ROWID", litr)
+				}
+				table.WithoutRowID = true
+				continue
+			case isWord(tok, litr, "STRICT") && p.sc.grammar().RowidTables():
+				table.Strict = true
+				continue
+			case tok == SEMI_COLON || tok == EOF || tok == OPEN_PARENTH || tok == SELECT,
+				isWord(tok, litr, "PARTITION"), isWord(tok, litr, "AS"),
+				isWord(tok, litr, "IGNORE"), isWord(tok, litr, "REPLACE"):
+				p.unScan()
+				return nil
+		}
 		p.unScan()
-		tok, litr = p.scanParenthIdent()
 
-		if tok!=IDENT{
-			return "", "", fmt.Errorf("found %q, expected", litr)
+		name, value, err := p.scanTableOption()
+		if err != nil{
+			return err
+		}
+		if err := table.setOption(name, value); err != nil{
+			return err
 		}
-		column=litr
-	}else{
-		return "", "", fmt.Errorf("found %q, expected ident", litr)
 	}
-
-	return index, column, nil 
 }
 
-func (p *Parser) scanConstraint()(*Constraint, error){
-	var constraint = &Constraint{}
-
+//Scan one [DEFAULT] name [=] value table option, returning the lower-cased
+//name; CHARACTER SET is returned as charset
+func (p *Parser) scanTableOption() (string, string, error){
 	tok, litr := p.scanIgnoreWhiteSpace()
-
-	if tok!=CONSTRAINT{
-		return nil, fmt.Errorf("found %q, expected CONSTRAINT", litr)
+	if tok == DEFAULT{
+		tok, litr = p.scanIgnoreWhiteSpace()
 	}
-
-	tok, litr = p.scanIdent()
-
-	if tok!=IDENT{
-		return nil, fmt.Errorf("found %q, expected ident", litr)
+	if tok != IDENT && !isKeyword(tok) || tok == EQUAL{
+		return "", "", fmt.Errorf("found %q, expected table option", litr)
 	}
 
-	constraint.Index=litr
-	tok1, litr1 := p.scanIgnoreWhiteSpace()
-	tok2, litr2 := p.scanIgnoreWhiteSpace()
-
-	if tok1 != FOREIGN || tok2 != KEY {
-		return nil, fmt.Errorf("found %q, expected FOREIGN KEY", litr1+litr2)
+	name := strings.ToLower(litr)
+	switch{
+		case tok == CHARACTER:
+			if tok1, litr1 := p.scanIgnoreWhiteSpace(); tok1 != SET{
+				return "", "", fmt.Errorf("found %q, expected CHARACTER SET", litr+" "+litr1)
+			}
+			name = "charset"
+		case tok == INDEX || isWord(tok, litr, "DATA"):
+			//DATA DIRECTORY and INDEX DIRECTORY
+			if tok1, litr1 := p.scanIgnoreWhiteSpace(); !isWord(tok1, litr1, "DIRECTORY"){
+				return "", "", fmt.Errorf("found %q, expected %s DIRECTORY", litr1, strings.ToUpper(litr))
+			}
+			name += " directory"
 	}
 
-	tok, litr = p.scanParenthIdent()
-	if tok != IDENT {
-		return nil, fmt.Errorf("found %q, expected ident", litr)
+	p.scanOptional(EQUAL)
+	value, err := p.scanTableOptionValue()
+	if err != nil{
+		return "", "", err
 	}
+	return name, value, nil
+}
 
-	constraint.ForeignKey=litr
-
-	tok, litr=p.scanIgnoreWhiteSpace()
-	if tok != REFERENCES{
-		return nil, fmt.Errorf("found %q, expected REFERENCES", litr)
+//Set a table option, filling the typed field of known options
+func (table *Table) setOption(name, value string) error{
+	var err error
+	table.Extras[name] = value
+
+	switch name{
+		case "engine":
+			table.Engine = value
+		case "charset":
+			table.Charset = value
+		case "collate":
+			table.Collation = value
+		case "auto_increment":
+			table.AutoIncrement, err = strconv.ParseInt(value, 10, 64)
+		case "row_format":
+			table.RowFormat = strings.ToUpper(value)
+		case "comment":
+			table.Comment = value
+		case "key_block_size":
+			table.KeyBlockSize, err = strconv.Atoi(value)
 	}
-
-	tok, litr = p.scanIdent()
-	if tok != IDENT {
-		return nil, fmt.Errorf("found %q, expected `table_name`", litr)
+	if err != nil{
+		return fmt.Errorf("found %q, expected integer %s", value, strings.ToUpper(name))
 	}
+	return nil
+}
 
-	constraint.TableName = litr
+//Scan the value of a table option: a word, number, string or a
+//parenthesized list such as UNION=(t1, t2), kept as written
+func (p *Parser) scanTableOptionValue() (string, error){
+	tok, litr := p.scanIgnoreWhiteSpace()
 
-	tok, litr = p.scanParenthIdent()
-	if tok != IDENT {
-		return nil, fmt.Errorf("found %q, expected (`column_name`)", litr)
-	}
+	switch{
+		case tok == IDENT || tok == STRING || tok == SIZE || isKeyword(tok) && tok != EQUAL:
+			return litr, nil
+		case tok == OPEN_PARENTH:
+			var list []string
+			for{
+				tok, litr = p.scanIdent()
+				if tok != IDENT{
+					return "", fmt.Errorf("found %q, expected ident", litr)
+				}
+				list = append(list, litr)
 
-	constraint.ColumnName = litr
+				if tok, litr = p.scanIgnoreWhiteSpace(); tok == CLOSE_PARENTH{
+					return "(" + strings.Join(list, ", ") + ")", nil
+				}else if tok != COMMA{
+					return "", fmt.Errorf("found %q, expected , or )", litr)
+				}
+			}
+	}
 
-	return constraint, nil
+	return "", fmt.Errorf("found %q, expected option value", litr)
 }
 
-func (p *Parser) scanKV() (string, string, error) {
-	tok, litr := p.scanIgnoreWhiteSpace()
-	tok1, litr1 := p.scanIgnoreWhiteSpace()
-	tok2, litr2 := p.scanIgnoreWhiteSpace()
-
-	if (tok != IDENT && tok != AUTO_INCREMENT) || tok1 != EQUAL || (tok2 != IDENT && tok2 != STRING && tok2 != SIZE) {
-		return "", "", fmt.Errorf("found %q, expected key=value", litr+litr1+litr2)
+//Skip the statement that has been started, reports false at EOF
+func (p *Parser) skipStatement() bool{
+	for{
+		if tok, _ := p.scanIgnoreWhiteSpace(); tok==SEMI_COLON{
+			return true
+		}else if tok == EOF{
+			return false
+		}
 	}
-	return litr, litr2, nil
 }
 
-func (p *Parser) scanExtra() (map[string]string, error){
-	extras := make(map[string]string)
+//Report whether parse skips the statements starting with tok in the
+//dialect: pg_dump writes SELECT set_config(...), grants and settings of its
+//own such as SET standard_conforming_strings = on, sqlite3 writes PRAGMA
+func (p *Parser) skipsStatement(tok Tokens, litr string) bool{
+	return (tok == IDENT || isKeyword(tok)) && p.sc.grammar().SkipsStatement(strings.ToUpper(litr))
+}
 
+//Parse the next statement that changes the schema
+func (p *Parser) parse() (Statement, error){
 	for{
-		if tok, _ := p.scanIgnoreWhiteSpace(); tok!=SEMI_COLON{
-
-			if tok != DEFAULT{
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok==LOCK || tok==UNLOCK || tok==ANNOTATION || p.skipsStatement(tok, litr){
+			if !p.skipStatement(){
+				return nil, nil
+			}
+		}else if tok==DROP{
+			//only DROP TABLE, INDEX, TRIGGER, VIEW and DATABASE change the schema, other objects are skipped
+			if tok, litr := p.scanIgnoreWhiteSpace(); tok==TABLE || tok==TEMPORARY{
+				p.unScan()
+				drop, err := p.parseDropTable()
+				if err != nil {
+					return nil, err
+				}
+				return drop, p.scanStatementEnd()
+			}else if tok==INDEX{
+				drop, err := p.parseDropIndex()
+				if err != nil {
+					return nil, err
+				}
+				return drop, p.scanStatementEnd()
+			}else if isWord(tok, litr, "TRIGGER"){
+				drop, err := p.parseDropTrigger()
+				if err != nil {
+					return nil, err
+				}
+				return drop, p.scanStatementEnd()
+			}else if isWord(tok, litr, "VIEW") || isWord(tok, litr, "MATERIALIZED"){
 				p.unScan()
+				drop, err := p.parseDropView()
+				if err != nil {
+					return nil, err
+				}
+				return drop, p.scanStatementEnd()
+			}else if isWord(tok, litr, "DATABASE") || isWord(tok, litr, "SCHEMA"){
+				drop, err := p.parseDropDatabase()
+				if err != nil {
+					return nil, err
+				}
+				return drop, p.scanStatementEnd()
 			}
-
-			k,v,err := p.scanKV()
-
-			if err!=nil {
-				return nil, err
+			if !p.skipStatement(){
+				return nil, nil
 			}
-
-			extras[k]=v
+		}else if tok==SEMI_COLON || tok==ANNOTATION{
+			continue
+		}else if tok==EOF{
+			return nil, nil
 		}else{
 			p.unScan()
-			break
+			stmt, err := p.ParseStatement()
+			if _, ok := err.(*unknownObjectError); ok && p.sc.grammar().SkipsUnknownObjects(){
+				//extensions, functions, virtual tables and other objects of
+				//PostgreSQL and SQLite schemas
+				if !p.skipStatement(){
+					return nil, nil
+				}
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			//other statements do not change the schema, USE changes the
+			//database of unqualified table names
+			switch stmt := stmt.(type){
+				case *UseStatement, *CreateTableStatement, *RenameTableStatement, *AlterTableStatement, *CreateIndexStatement,
+					*CreateViewStatement, *CreateTriggerStatement, *CommentStatement:
+					return stmt, nil
+			}
 		}
 	}
-
-	return extras, nil 
 }
 
-//Parse one table
-func (p *Parser) parse() (*Table, error){
-	table := &Table{
+//newTable returns an empty table
+func newTable(name string) *Table{
+	return &Table{
+		Name:        	name,
 		Columns: 		make(map[string]*Column),
-		UniqueKeys:  	make(map[string]string),
-		Keys:        	make(map[string]string),
+		UniqueKeys:  	make(map[string]*Index),
+		Keys:        	make(map[string]*Index),
 		Constraints: 	make(map[string]*Constraint),
+		Checks:      	make(map[string]*CheckConstraint),
 		Extras:      	make(map[string]string),
 	}
+}
 
-	for{
-		if tok, litr := p.scanIgnoreWhiteSpace(); tok==DROP || tok==LOCK || tok==UNLOCK || tok==ANNOTATION{
-			for{
-				if tok, _ := p.scanIgnoreWhiteSpace(); tok==SEMI_COLON{
-					break
-				}else if tok == EOF{
-					return nil, nil
+//Parse the table definition following CREATE [TEMPORARY] TABLE:
+//[IF NOT EXISTS] name {LIKE other | [(definitions)] [options] [[AS] SELECT ...]}
+func (p *Parser) parseCreateTable(temporary bool) (*CreateTableStatement, error){
+	stmt := &CreateTableStatement{Temporary: temporary}
+
+	var err error
+	if stmt.IfNotExists, err = p.scanIfNotExists(); err != nil{
+		return nil, err
+	}
+
+	name, err := p.scanQualifiedName("table name")
+	if err != nil{
+		return nil, err
+	}
+	if name.Schema == ""{
+		name.Schema = p.database
+	}
+	table := newTable(name.Name)
+	table.Catalog, table.Database = name.Catalog, name.Schema
+	table.Temporary = temporary
+	stmt.Table = table
+
+	switch tok, _ := p.scanIgnoreWhiteSpace(); tok{
+		case LIKE:
+			stmt.Like, err = p.scanTableName()
+			return stmt, err
+
+		case OPEN_PARENTH:
+			//CREATE TABLE t (LIKE other)
+			if p.scanOptional(LIKE){
+				if stmt.Like, err = p.scanTableName(); err != nil{
+					return nil, err
+				}
+				if tok, litr := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH{
+					return nil, fmt.Errorf("found %q, expected )", litr)
 				}
+				return stmt, nil
 			}
-		}else if tok==SEMI_COLON || tok==ANNOTATION{
-			continue
-		}else if tok==CREATE{
-			break
-		}else if tok==EOF{
-			return nil, nil
-		}else{
-			return nil, fmt.Errorf("unexpected %v: %q", tok, litr)
-		}
+
+			if err := p.scanTableDefinitions(table); err != nil{
+				return nil, err
+			}
+
+		default:
+			//CREATE TABLE t AS SELECT has no definitions
+			p.unScan()
 	}
 
-	if tok, litr := p.scanIgnoreWhiteSpace(); tok != TABLE {
-		return nil, fmt.Errorf("found CREATE %q, expected CREATE TABLE", litr)
+	if err := p.scanTableOptions(table); err != nil {
+		return nil, err
 	}
+	table.resolveCharsets()
 
-	if tok, litr := p.scanIdent(); tok==IDENT{
-		table.Name=litr
+	if tok, litr := p.scanIgnoreWhiteSpace(); isWord(tok, litr, "PARTITION"){
+		if table.Partitioning, err = p.scanPartitioning(); err != nil{
+			return nil, err
+		}
 	}else{
-		return nil, fmt.Errorf("found CREATE TABLE %d %q, expected CREATE TABLE `ident`", tok, litr)
+		p.unScan()
 	}
 
-	//scan columns 
-	if tok, litr := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH{
-		return nil, fmt.Errorf("found %q, expected (", litr)
+	//[IGNORE|REPLACE] [AS] SELECT ...
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if isWord(tok, litr, "IGNORE") || isWord(tok, litr, "REPLACE"){
+		stmt.Duplicates = strings.ToUpper(litr)
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+	as := isWord(tok, litr, "AS")
+	if as{
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+	p.unScan()
+	if tok == SELECT{
+		if table.AsSelect, err = p.ParseSelectStatements(); err != nil{
+			return nil, err
+		}
+	}else if as || stmt.Duplicates != ""{
+		return nil, fmt.Errorf("found %q, expected SELECT", litr)
 	}
 
+	return stmt, nil
+}
+
+//Scan the column, index and constraint definitions of a table up to and
+//including the closing parenthesis
+func (p *Parser) scanTableDefinitions(table *Table) error{
 	for{
 		tok, litr := p.scanIgnoreWhiteSpace()
+		if p.sc.nonReserved(tok){
+			tok = IDENT
+		}
 
 		switch tok{
 
 			case IDENT: 
 				p.unScan()
-				col, err := p.scanColumn()
+				col, err := p.scanColumn(table)
 
 				if err!=nil {
-					return nil, err
+					return err
 				}
-				table.Columns[col.Name]=col
-
-			case PRIMARY:
-				p.unScan()
-				key, err := p.scanPrimarykey()
+				table.addColumn(col)
 
+			case PRIMARY, UNIQUE, KEY, INDEX, FULLTEXT, SPATIAL:
+				index, err := p.scanTableIndex(tok, litr)
 				if err!=nil {
-					return nil, err
+					return err
 				}
-				table.PrimaryKey=key
+				table.addIndex(index)
 
-			case UNIQUE:
-				k, v, err := p.scanKey()
-				if err!=nil {
-					return nil, err
+			case CONSTRAINT:
+				p.unScan()
+				if err := p.scanConstraint(table); err != nil {
+					return err
 				}
-				table.UniqueKeys[k]=v
 
-			case KEY:
-				p.unScan()
-				index, col, err := p.scanKey()
-				if err!=nil{
-					return nil, err
+			case CHECK:
+				check, err := p.scanCheck()
+				if err != nil {
+					return err
+				}
+				if err := p.scanNotEnforced(check); err != nil {
+					return err
 				}
-				table.Keys[index]=col
+				table.addCheck(check)
 
-			case CONSTRAINT:
-				p.unScan()
-				cos, err := p.scanConstraint()
+			case FOREIGN:
+				cos, err := p.scanForeignKey()
 				if err != nil {
-					return nil, err
+					return err
 				}
-				table.Constraints[cos.ForeignKey]=cos
+				table.addConstraint(cos)
 
 			case CLOSE_PARENTH:
-				tok, litr = p.scanIgnoreWhiteSpace()
-				if tok != SEMI_COLON {
-					p.unScan()
-					extras, err := p.scanExtra()
-					if err != nil {
-						return nil, err
-					}
-					table.Extras = extras
-				}
-				return table, nil
+				return nil
 
 			case COMMA:
 				continue
@@ -502,28 +1653,52 @@ func (p *Parser) parse() (*Table, error){
 				continue
 
 			case SEMI_COLON:
-				return table, nil
+				p.unScan()
+				return nil
+
+			case EOF:
+				return fmt.Errorf("Unexpected EOF")
 
 			default:
-				return nil, fmt.Errorf("found %q, expected ident or primary or unique or key or constraint", litr)
+				return fmt.Errorf("found %q, expected ident or primary or unique or key or constraint", litr)
 		}	
 
 	}
 }
 
-// Parse returns parsed table schema and an error
+// CreateTableStatement is CREATE [TEMPORARY] TABLE [IF NOT EXISTS] name
+// followed by (definitions) [options] [AS SELECT ...] or LIKE other.
+type CreateTableStatement struct {
+	Table       *Table
+	Temporary   bool
+	IfNotExists bool
+	Like        string // table copied by CREATE TABLE ... LIKE
+	Duplicates  string // "IGNORE" or "REPLACE" before AS SELECT
+}
+
+// Database returns the database of the last USE statement read, "" before
+// any; the table names of the statements after it are relative to it.
+func (p *Parser) Database() string {
+	return p.database
+}
+
+// Parse returns parsed table schema and an error. Tables are keyed by their
+// names as written after the last USE: the tables of its database by their
+// unqualified names, the others as db.table.
 func (p *Parser) Parse() (Schema, error) {
 	schema := make(Schema)
 	for {
-		table, err := p.parse()
+		stmt, err := p.parse()
 		
 		if err != nil {
 			return schema, err // return already parsed tables and error
 		}
-		if table == nil { // parse done
+		if stmt == nil { // parse done
 			break
 		}
-		schema[table.Name] = table
+		if err := schema.apply(stmt, p.database); err != nil {
+			return schema, err
+		}
 	}
 	return schema, nil
 }
@@ -537,27 +1712,58 @@ func (p *Parser) Parse() (Schema, error) {
 
 type SelectStatement struct {
 	Fields    []string
+	Exprs     []Expr   // the expressions of the Fields, nil for a * field
+	Aliases   []string // the AS names of the Fields, "" for a field without one; nil when none has one
 	TableName string
+	Where     Expr
 }
 
 type InsertStatement struct {
-	Fields    []string
+	Fields    []string // column names followed by the values as text
 	TableName string
+	Columns   []string
+	Rows      [][]Expr
 }
 
 type DeleteStatement struct {
 	Fields    []string
 	TableName string
+	Where     Expr
 }
 
 type UpdateStatement struct {
-	Fields    []string
+	Fields    []string // assigned columns and values, then the columns of WHERE
 	TableName string
+	Set       []*Assignment
+	Where     Expr
+}
+
+// Assignment is a column = value pair of UPDATE ... SET.
+type Assignment struct {
+	Column string
+	Value  Expr
+}
+
+// exprField returns the text of a value as recorded in a statement's Fields.
+func exprField(e Expr) string {
+	if str, ok := e.(*StringLit); ok {
+		return str.Value
+	}
+	return e.String()
+}
+
+// scanWhere parses an optional WHERE clause.
+func (p *Parser) scanWhere() (Expr, error) {
+	if !p.scanOptional(WHERE) {
+		return nil, nil
+	}
+	return p.ParseExpr()
 }
 
 // This function parses SQL SELECT statements.
 func (p *Parser) ParseSelectStatements() (*SelectStatement, error) {
 	stmt := &SelectStatement{}
+	p.params = 0
 
 	// First token should be a "SELECT" keyword.
 	if tok, lit := p.scanIgnoreWhiteSpace(); tok != SELECT {
@@ -565,13 +1771,38 @@ func (p *Parser) ParseSelectStatements() (*SelectStatement, error) {
 	}
 
 	// Next we should loop over all our comma-delimited fields.
+	var aliased bool
 	for {
-		// Read a field.
+		// Read a field: *, a column possibly qualified as table.field or
+		// db.table.*, or another expression.
 		tok, lit := p.scanIgnoreWhiteSpace()
-		if tok != IDENT && tok != ASTERISK {
+		if tok == ILLEGAL || tok == EOF {
 			return nil, fmt.Errorf("found %q, expected field", lit)
 		}
-		stmt.Fields = append(stmt.Fields, lit)
+		field := lit
+		var x Expr
+		if tok != ASTERISK {
+			p.unScan()
+			var err error
+			if x, err = p.ParseExpr(); err != nil {
+				return nil, err
+			}
+			field = x.String()
+		}
+		stmt.Fields = append(stmt.Fields, field)
+		stmt.Exprs = append(stmt.Exprs, x)
+
+		// Then an optional AS alias.
+		var alias string
+		if tok, lit := p.scanIgnoreWhiteSpace(); isWord(tok, lit, "AS") {
+			if tok, alias = p.scanIdent(); tok != IDENT {
+				return nil, fmt.Errorf("found %q, expected alias", alias)
+			}
+			aliased = true
+		} else {
+			p.unScan()
+		}
+		stmt.Aliases = append(stmt.Aliases, alias)
 
 		// If the next token is not a comma then break the loop.
 		if tok, _ := p.scanIgnoreWhiteSpace(); tok != COMMA {
@@ -579,18 +1810,34 @@ func (p *Parser) ParseSelectStatements() (*SelectStatement, error) {
 			break
 		}
 	}
+	if !aliased {
+		stmt.Aliases = nil
+	}
 
-	// Next we should see the "FROM" keyword.
-	if tok, lit := p.scanIgnoreWhiteSpace(); tok != FROM {
+	// Next we should see the "FROM" keyword, unless the query selects
+	// values only, like SELECT 1 AS a.
+	switch tok, lit := p.scanIgnoreWhiteSpace(); tok {
+	case EOF, SEMI_COLON:
+		p.unScan()
+		return stmt, nil
+	case FROM:
+	default:
 		return nil, fmt.Errorf("found %q, expected FROM", lit)
 	}
 
-	// Finally we should read the table name.
-	tok, lit := p.scanIgnoreWhiteSpace()
-	if tok != IDENT {
-		return nil, fmt.Errorf("found %q, expected table name", lit)
+	// Then we should read the table name.
+	name, err := p.scanTableName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.TableName = name
+
+	// Finally an optional WHERE clause.
+	where, err := p.scanWhere()
+	if err != nil {
+		return nil, err
 	}
-	stmt.TableName = lit
+	stmt.Where = where
 
 	// Return the successfully parsed statement.
 	return stmt, nil
@@ -600,71 +1847,87 @@ func (p *Parser) ParseSelectStatements() (*SelectStatement, error) {
 func (p *Parser) ParseInsertStatements() (*InsertStatement, error) {
 	stmtins := &InsertStatement{}
 
+	err := p.parseInsert(stmtins, func(row []Expr) error {
+		for _, value := range row {
+			stmtins.Fields = append(stmtins.Fields, exprField(value))
+		}
+		stmtins.Rows = append(stmtins.Rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the successfully parsed statement.
+	return stmtins, nil
+}
+
+// parseInsert parses an INSERT statement into stmtins, handing each row of
+// VALUES to row as soon as it is read instead of storing it.
+func (p *Parser) parseInsert(stmtins *InsertStatement, row func([]Expr) error) error {
+	p.params = 0
 	//First token should be a "INSERT" keyword.
 	if tok, lit := p.scanIgnoreWhiteSpace(); tok != INSERT {
-		return nil, fmt.Errorf("found %q, expected INSERT", lit)
+		return fmt.Errorf("found %q, expected INSERT", lit)
 	}
 
 	//Next keyword should be INTO
 	if tok, lit := p.scanIgnoreWhiteSpace(); tok != INTO {
-		return nil, fmt.Errorf("found %q, expected INTO", lit)
+		return fmt.Errorf("found %q, expected INTO", lit)
 	}
 
 	//Next keyword should denote table name.
-	tok, lit := p.scanIgnoreWhiteSpace()
-	if tok != IDENT {
-		return nil, fmt.Errorf("found %q, expected table name", lit)
-	}
-	stmtins.TableName = lit
-
-	//Check if the column names start with '('
-	if tok, lit := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH {
-		return nil, fmt.Errorf("found %q, expected OPEN_PARENTH", lit)
+	name, err := p.scanTableName()
+	if err != nil {
+		return err
 	}
+	stmtins.TableName = name
+
+	//The column names are optional and start with '('
+	if p.scanOptional(OPEN_PARENTH) {
+		//Next we should loop over all our comma-delimited fields.
+		for {
+			// Read a field.
+			tok, lit := p.scanIgnoreWhiteSpace()
+			if tok != IDENT {
+				return fmt.Errorf("found %q, expected field", lit)
+			}
+			stmtins.Fields = append(stmtins.Fields, lit)
+			stmtins.Columns = append(stmtins.Columns, lit)
 
-	//Next we should loop over all our comma-delimited fields.
-	for {
-		// Read a field.
-
-		tok, lit := p.scanIgnoreWhiteSpace()
-		if tok != IDENT {
-			return nil, fmt.Errorf("found %q, expected field", lit)
+			// If the next token is not a comma then break the loop.
+			if tok, _ := p.scanIgnoreWhiteSpace(); tok != COMMA {
+				p.unScan()
+				break
+			}
 		}
-		stmtins.Fields = append(stmtins.Fields, lit)
 
-
-		// If the next token is not a comma then break the loop.
-		if tok, _ := p.scanIgnoreWhiteSpace(); tok != COMMA {
-			p.unScan()
-			break
+		//Check if the column names end with ')'
+		if tok, lit := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH {
+			return fmt.Errorf("found %q, expected CLOSE_PARENTH", lit)
 		}
 	}
 
-	//Check if the column names end with ')'
-	if tok, lit := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH {
-		return nil, fmt.Errorf("found %q, expected CLOSE_PARENTH", lit)
-	}
-
-	//After loop, next token should be "VALUES" keyword.
+	//Next token should be "VALUES" keyword.
 	if tok, lit := p.scanIgnoreWhiteSpace(); tok != VALUES {
-		return nil, fmt.Errorf("found %q, expected VALUES", lit)
-	}
-
-	//Check if the string values start with '('
-	if tok, lit := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH {
-		return nil, fmt.Errorf("found %q, expected OPEN_PARENTH", lit)
+		return fmt.Errorf("found %q, expected VALUES", lit)
 	}
 
-	//Next we should loop over all our comma-delimited fields.
+	//Next we should loop over all our comma-delimited rows.
 	for {
-		// Read a field.
-
-		tok, lit := p.scanIgnoreWhiteSpace()
-		if tok != STRING {
-			return nil, fmt.Errorf("found %q, expected field", lit)
+		//Check if the values start with '('
+		if tok, lit := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH {
+			return fmt.Errorf("found %q, expected OPEN_PARENTH", lit)
 		}
-		stmtins.Fields = append(stmtins.Fields, lit)
 
+		//Read the values up to and including ')'
+		values, err := p.parseExprList()
+		if err != nil {
+			return err
+		}
+		if err := row(values); err != nil {
+			return err
+		}
 
 		// If the next token is not a comma then break the loop.
 		if tok, _ := p.scanIgnoreWhiteSpace(); tok != COMMA {
@@ -673,18 +1936,13 @@ func (p *Parser) ParseInsertStatements() (*InsertStatement, error) {
 		}
 	}
 
-	//Check if the string values end with ')'
-	if tok, lit := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH {
-		return nil, fmt.Errorf("found %q, expected CLOSE_PARENTH", lit)
-	}
-
-	// Return the successfully parsed statement.
-	return stmtins, nil
+	return nil
 }
 
 // This function parses SQL DELETE statements.
 func (p *Parser) ParseDeleteStatements() (*DeleteStatement, error) {
 	stmtdel := &DeleteStatement{}
+	p.params = 0
 
 	// First token should be a "SELECT" keyword.
 	if tok, lit := p.scanIgnoreWhiteSpace(); tok != DELETE {
@@ -713,11 +1971,18 @@ func (p *Parser) ParseDeleteStatements() (*DeleteStatement, error) {
 	}
 
 	//we should read the table name.
-	tok, lit := p.scanIgnoreWhiteSpace()
-	if tok != IDENT {
-		return nil, fmt.Errorf("found %q, expected table name", lit)
+	name, err := p.scanTableName()
+	if err != nil {
+		return nil, err
+	}
+	stmtdel.TableName = name
+
+	// Finally an optional WHERE clause.
+	where, err := p.scanWhere()
+	if err != nil {
+		return nil, err
 	}
-	stmtdel.TableName = lit
+	stmtdel.Where = where
 
 	// Return the successfully parsed statement.
 	return stmtdel, nil
@@ -726,6 +1991,7 @@ func (p *Parser) ParseDeleteStatements() (*DeleteStatement, error) {
 // This function parses SQL UPDATE statements.
 func (p *Parser) ParseUpdateStatements() (*UpdateStatement, error) {
 	stmtupdate := &UpdateStatement{}
+	p.params = 0
 
 	// First token should be a "SELECT" keyword.
 	if tok, lit := p.scanIgnoreWhiteSpace(); tok != UPDATE {
@@ -733,11 +1999,11 @@ func (p *Parser) ParseUpdateStatements() (*UpdateStatement, error) {
 	}
 
 	// Finally we should read the table name.
-	tok, lit := p.scanIgnoreWhiteSpace()
-	if tok != IDENT {
-		return nil, fmt.Errorf("found %q, expected table name", lit)
+	name, err := p.scanTableName()
+	if err != nil {
+		return nil, err
 	}
-	stmtupdate.TableName = lit
+	stmtupdate.TableName = name
 
 	// Next we should see the "SET" keyword.
 	if tok, lit := p.scanIgnoreWhiteSpace(); tok != SET {
@@ -753,22 +2019,16 @@ func (p *Parser) ParseUpdateStatements() (*UpdateStatement, error) {
 		}
 		stmtupdate.Fields = append(stmtupdate.Fields, lit)
 
-		/*
-		tok, lit := p.scanIgnoreWhiteSpace()
-		if tok != EQUAL {
-			return nil, fmt.Errorf("found %q, expected field", lit)
-		}
-		*/
-		
-		if tok, _ := p.scanIgnoreWhiteSpace(); tok != EQUAL {
-			return nil, fmt.Errorf("found %q, expected field", lit)
+		if tok, lit := p.scanIgnoreWhiteSpace(); tok != EQUAL {
+			return nil, fmt.Errorf("found %q, expected =", lit)
 		}
 
-		tok, lit = p.scanIgnoreWhiteSpace()
-		if tok != STRING {
-			return nil, fmt.Errorf("found %q, expected field", lit)
+		value, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
 		}
-		stmtupdate.Fields = append(stmtupdate.Fields, lit)
+		stmtupdate.Fields = append(stmtupdate.Fields, exprField(value))
+		stmtupdate.Set = append(stmtupdate.Set, &Assignment{Column: lit, Value: value})
 
 		// If the next token is not a comma then break the loop.
 		if tok, _ := p.scanIgnoreWhiteSpace(); tok != COMMA {
@@ -777,26 +2037,19 @@ func (p *Parser) ParseUpdateStatements() (*UpdateStatement, error) {
 		}
 
 	}
-	
-	tok, lit = p.scanIgnoreWhiteSpace()
-	if tok != WHERE {
-		return nil, fmt.Errorf("found %q, expected field", lit)
-	}
-
-	tok, lit = p.scanIgnoreWhiteSpace()
-	if tok != IDENT {
-		return nil, fmt.Errorf("found %q, expected field", lit)
-	}
-	stmtupdate.Fields = append(stmtupdate.Fields, lit)
-	
-	if tok, _ := p.scanIgnoreWhiteSpace(); tok != EQUAL {
-			return nil, fmt.Errorf("found %q, expected field", lit)
-	}
 
-	tok, lit = p.scanIgnoreWhiteSpace()
-	if tok != SIZE {
-		return nil, fmt.Errorf("found %q, expected field", lit)
+	// Finally an optional WHERE clause, whose columns are recorded as fields.
+	where, err := p.scanWhere()
+	if err != nil {
+		return nil, err
 	}
+	stmtupdate.Where = where
+	Inspect(where, func(e Expr) bool {
+		if id, ok := e.(*Ident); ok {
+			stmtupdate.Fields = append(stmtupdate.Fields, id.Name)
+		}
+		return true
+	})
 
 	// Return the successfully parsed statement.
 	return stmtupdate, nil
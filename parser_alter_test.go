@@ -0,0 +1,223 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Alter_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{
+			s: "ALTER TABLE `user` DROP COLUMN age, DROP email, DROP PRIMARY KEY, DROP INDEX idx_name, DROP FOREIGN KEY fk_a, DROP CHECK chk, DROP CONSTRAINT c",
+			stmt: &SQLParser.AlterTableStatement{Table: "user", Specs: []*SQLParser.AlterSpec{
+				{Action: "DROP COLUMN", Name: "age"},
+				{Action: "DROP COLUMN", Name: "email"},
+				{Action: "DROP INDEX", Name: "PRIMARY"},
+				{Action: "DROP INDEX", Name: "idx_name"},
+				{Action: "DROP FOREIGN KEY", Name: "fk_a"},
+				{Action: "DROP CHECK", Name: "chk"},
+				{Action: "DROP CONSTRAINT", Name: "c"},
+			}},
+		},
+		{
+			s: `ALTER TABLE a RENAME COLUMN x TO y, RENAME INDEX i TO j, RENAME TO b`,
+			stmt: &SQLParser.AlterTableStatement{Table: "a", Specs: []*SQLParser.AlterSpec{
+				{Action: "RENAME COLUMN", Name: "x", NewName: "y"},
+				{Action: "RENAME INDEX", Name: "i", NewName: "j"},
+				{Action: "RENAME TABLE", NewName: "b"},
+			}},
+		},
+		{
+			s: `ALTER TABLE a ALTER COLUMN x SET DEFAULT 5, ALTER y DROP DEFAULT`,
+			stmt: &SQLParser.AlterTableStatement{Table: "a", Specs: []*SQLParser.AlterSpec{
+				{Action: "SET DEFAULT", Name: "x", Default: &SQLParser.NumberLit{Value: "5"}},
+				{Action: "DROP DEFAULT", Name: "y"},
+			}},
+		},
+		{
+			s: `ALTER TABLE a ENGINE=MyISAM, AUTO_INCREMENT = 10, ALGORITHM=INPLACE, CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_bin, DISABLE KEYS`,
+			stmt: &SQLParser.AlterTableStatement{Table: "a", Specs: []*SQLParser.AlterSpec{
+				{Action: "OPTION", Name: "engine", Value: "MyISAM"},
+				{Action: "OPTION", Name: "auto_increment", Value: "10"},
+				{Action: "OPTION", Name: "algorithm", Value: "INPLACE"},
+				{Action: "CONVERT", Value: "utf8mb4", Collation: "utf8mb4_bin"},
+				{Action: "DISABLE KEYS"},
+			}},
+		},
+		{
+			s: `ALTER TABLE a ADD UNIQUE KEY uk (x), ADD CONSTRAINT fk FOREIGN KEY (x) REFERENCES b (id)`,
+			stmt: &SQLParser.AlterTableStatement{Table: "a", Specs: []*SQLParser.AlterSpec{
+				{Action: "ADD INDEX", Index: &SQLParser.Index{Name: "uk", Kind: "UNIQUE", Columns: []*SQLParser.IndexColumn{{Name: "x"}}}},
+				{Action: "ADD FOREIGN KEY", ForeignKey: &SQLParser.Constraint{
+					Index: "fk", ForeignKey: "x", TableName: "b", ColumnName: "id",
+					Columns: []string{"x"}, RefColumns: []string{"id"},
+				}},
+			}},
+		},
+
+		// Errors
+		{s: `ALTER DATABASE shop`, err: `found ALTER "DATABASE", expected ALTER TABLE`},
+		{s: `ALTER TABLE a`, err: `found "EOF", expected alter specification`},
+		{s: `ALTER TABLE a DROP PRIMARY x`, err: `found "x", expected PRIMARY KEY`},
+		{s: `ALTER TABLE a ALTER x SET 5`, err: `found "5", expected SET DEFAULT`},
+		{s: `ALTER TABLE a ALTER x ADD DEFAULT`, err: `found "DEFAULT", expected ADD GENERATED`},
+		{s: `ALTER TABLE a RENAME COLUMN x y`, err: `found "y", expected TO`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
+
+func Test_Alter_ColumnSpecs(t *testing.T) {
+	s := "ALTER TABLE a ADD COLUMN b varchar(10) NOT NULL UNIQUE AFTER id, MODIFY c int FIRST, CHANGE d e int, ADD (f int, g int)"
+	stmt, err := SQLParser.NewParser(strings.NewReader(s)).ParseStatement()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, spec := range stmt.(*SQLParser.AlterTableStatement).Specs {
+		desc := spec.Action + " " + spec.Name
+		if spec.Column != nil {
+			desc += " " + spec.Column.Name + " " + spec.Column.Type
+		}
+		if spec.First {
+			desc += " FIRST"
+		}
+		if spec.After != "" {
+			desc += " AFTER " + spec.After
+		}
+		if spec.Index != nil {
+			desc += spec.Index.Kind + " " + spec.Index.Columns[0].Name
+		}
+		got = append(got, desc)
+	}
+
+	exp := []string{
+		"ADD COLUMN b b varchar AFTER id",
+		"ADD INDEX UNIQUE b",
+		"MODIFY COLUMN c c int FIRST",
+		"CHANGE COLUMN d e int",
+		"ADD COLUMN f f int",
+		"ADD COLUMN g g int",
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("specs mismatch:\n  exp=%q\n  got=%q", exp, got)
+	}
+}
+
+func Test_Alter_SchemaReplay(t *testing.T) {
+	s := "CREATE TABLE user (\n  `id` int NOT NULL,\n  `name` varchar(20),\n  `age` int,\n  PRIMARY KEY (`id`),\n  KEY `idx_name` (`name`, `age`)\n) ENGINE=InnoDB;\n" +
+		"CREATE TABLE city (\n  `id` int NOT NULL,\n  PRIMARY KEY (`id`)\n);\n" +
+		"ALTER TABLE user ADD COLUMN email varchar(50) NOT NULL AFTER id, DROP COLUMN age;\n" +
+		"ALTER TABLE user CHANGE name full_name varchar(40), ADD city_id int, ADD CONSTRAINT fk_city FOREIGN KEY (city_id) REFERENCES city (id);\n" +
+		"ALTER TABLE user ALTER email SET DEFAULT '', ENGINE=MyISAM, ADD CHECK (id > 0), RENAME INDEX idx_name TO idx_full_name;\n" +
+		"ALTER TABLE user RENAME TO member;"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := schema["member"]
+	if table == nil || schema["user"] != nil {
+		t.Fatalf("expected user to be renamed to member, found %v", schema.TableNames())
+	}
+	if table.Name != "member" || table.Engine != "MyISAM" {
+		t.Errorf("expected member with engine MyISAM, found %q with %q", table.Name, table.Engine)
+	}
+
+	var columns []string
+	for _, column := range table.ColumnList {
+		columns = append(columns, column.Name)
+	}
+	if exp := []string{"id", "email", "full_name", "city_id"}; !reflect.DeepEqual(exp, columns) {
+		t.Errorf("columns mismatch:\n  exp=%q\n  got=%q", exp, columns)
+	}
+	if table.Columns["full_name"] == nil || table.Columns["full_name"].Size != 40 {
+		t.Errorf("expected full_name varchar(40), found %#v", table.Columns["full_name"])
+	}
+	if exp := (&SQLParser.StringLit{Value: ""}); !reflect.DeepEqual(exp, table.Columns["email"].Default) {
+		t.Errorf("expected email default %#v, found %#v", exp, table.Columns["email"].Default)
+	}
+
+	index := table.Keys["idx_full_name"]
+	if index == nil || len(index.Columns) != 1 || index.Columns[0].Name != "full_name" {
+		t.Errorf("expected idx_full_name on full_name, found %#v", index)
+	}
+	if table.Constraints["fk_city"] == nil || table.Constraints["fk_city"].TableName != "city" {
+		t.Errorf("expected fk_city referencing city, found %v", table.Constraints)
+	}
+	if table.Checks["user_chk_1"] == nil {
+		t.Errorf("expected user_chk_1, found %v", table.Checks)
+	}
+}
+
+func Test_Alter_Atomic(t *testing.T) {
+	schema, err := SQLParser.NewParser(strings.NewReader("CREATE TABLE t (a int, b int, KEY idx_b (b));")).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := schema["t"]
+
+	stmt, err := SQLParser.NewParser(strings.NewReader("ALTER TABLE t DROP COLUMN a, RENAME COLUMN b TO c, RENAME TO u, DROP COLUMN nope")).ParseStatement()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.Apply(stmt); errstring(err) != `cannot drop column "nope", it does not exist` {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if exp := []string{"t"}; !reflect.DeepEqual(exp, schema.TableNames()) || schema["t"] != table {
+		t.Fatalf("expected the table to stay t, found %v", schema.TableNames())
+	}
+	if table.Name != "t" || table.Columns["a"] == nil || table.Columns["b"] == nil || table.Columns["c"] != nil || len(table.ColumnList) != 2 {
+		t.Errorf("expected the table to be unchanged, found %q with %v", table.Name, table.ColumnList)
+	}
+	if index := table.Keys["idx_b"]; index == nil || index.Columns[0].Name != "b" {
+		t.Errorf("expected idx_b to stay on b, found %#v", index)
+	}
+}
+
+func Test_Alter_SchemaErrors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: `ALTER TABLE missing ADD x int`, err: `cannot alter "missing", table does not exist`},
+		{s: `ALTER TABLE a ADD id int`, err: `cannot add column "id", it already exists`},
+		{s: `ALTER TABLE a DROP COLUMN x`, err: `cannot drop column "x", it does not exist`},
+		{s: `ALTER TABLE a MODIFY x int`, err: `cannot change column "x", it does not exist`},
+		{s: `ALTER TABLE a ADD x int AFTER y`, err: `cannot place column "x" after "y", it does not exist`},
+		{s: `ALTER TABLE a ADD PRIMARY KEY (id)`, err: `cannot add a primary key to "a", it already has one`},
+		{s: `ALTER TABLE a DROP INDEX i`, err: `cannot drop index "i", it does not exist`},
+		{s: `ALTER TABLE a RENAME TO b`, err: `cannot rename "a" to "b", table already exists`},
+		{s: `ALTER TABLE a ALTER id SET NULL`, err: `found "NULL", expected SET DEFAULT`},
+		{s: `ALTER TABLE c DROP COLUMN p`, err: `cannot drop column "p", foreign key "fk_p" uses it`},
+		{s: `ALTER TABLE c DROP COLUMN q`, err: `cannot drop column "q", check "q_positive" uses it`},
+		{s: `ALTER TABLE c DROP CHECK q_positive, DROP COLUMN q`, err: `cannot drop column "q", generated column "r" uses it`},
+		{s: `ALTER TABLE c RENAME COLUMN q TO s`, err: `cannot rename column "q", check "q_positive" uses it`},
+		{s: `ALTER TABLE c DROP CHECK q_positive, CHANGE q s int`, err: `cannot rename column "q", generated column "r" uses it`},
+		{s: `ALTER TABLE c DROP FOREIGN KEY fk_p, DROP COLUMN p, DROP COLUMN r, DROP CHECK q_positive, DROP COLUMN q`},
+	}
+
+	for i, tt := range tests {
+		s := "CREATE TABLE a (id int, PRIMARY KEY (id));\nCREATE TABLE b (id int);\n" +
+			"CREATE TABLE c (p int, q int, r int AS (q * 2), CONSTRAINT q_positive CHECK (q > 0), CONSTRAINT fk_p FOREIGN KEY (p) REFERENCES a (id));\n" + tt.s
+		_, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
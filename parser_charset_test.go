@@ -0,0 +1,111 @@
+package SQLParser_test
+
+import (
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Charset_Parser(t *testing.T) {
+	s := "CREATE TABLE `user` (\n" +
+		"  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `code` int(6) unsigned zerofill,\n" +
+		"  `delta` int signed,\n" +
+		"  `name` varchar(255) NOT NULL,\n" +
+		"  `login` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,\n" +
+		"  `note` text CHARSET latin1,\n" +
+		"  `tag` varchar(16) COLLATE ascii_general_ci,\n" +
+		"  `raw` varchar(16) CHARACTER SET binary\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;"
+
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := schema["user"].Columns
+
+	var tests = []struct {
+		name      string
+		unsigned  bool
+		zerofill  bool
+		charset   string
+		collation string
+	}{
+		{name: "id", unsigned: true},
+		{name: "code", unsigned: true, zerofill: true},
+		{name: "delta"},
+		{name: "name", charset: "utf8", collation: "utf8_unicode_ci"},
+		{name: "login", charset: "utf8mb4", collation: "utf8mb4_bin"},
+		{name: "note", charset: "latin1"},
+		{name: "tag", charset: "ascii", collation: "ascii_general_ci"},
+		{name: "raw", charset: "binary"},
+	}
+
+	for i, tt := range tests {
+		c := columns[tt.name]
+		if c == nil {
+			t.Errorf("%d. column %q not found", i, tt.name)
+			continue
+		}
+		if c.Unsigned != tt.unsigned || c.Zerofill != tt.zerofill || c.Charset != tt.charset || c.Collation != tt.collation {
+			t.Errorf("%d. column %q mismatch: got unsigned=%v zerofill=%v charset=%q collation=%q",
+				i, tt.name, c.Unsigned, c.Zerofill, c.Charset, c.Collation)
+		}
+	}
+}
+
+func Test_Charset_TableCharacterSet(t *testing.T) {
+	s := "CREATE TABLE t (a char(1), b int) DEFAULT CHARACTER SET=latin1;"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if charset := schema["t"].Columns["a"].Charset; charset != "latin1" {
+		t.Errorf("expected charset latin1, found %q", charset)
+	}
+	if charset := schema["t"].Columns["b"].Charset; charset != "" {
+		t.Errorf("expected no charset on an int column, found %q", charset)
+	}
+}
+
+func Test_Charset_QuotedCollation(t *testing.T) {
+	s := "CREATE TABLE t (a varchar(8) COLLATE 'utf8mb4_bin', b char(1) COLLATE binary);" +
+		"ALTER TABLE t MODIFY b char(2) COLLATE 'latin1_bin';"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if collation := schema["t"].Columns["a"].Collation; collation != "utf8mb4_bin" {
+		t.Errorf("expected collation utf8mb4_bin, found %q", collation)
+	}
+	if collation := schema["t"].Columns["b"].Collation; collation != "latin1_bin" {
+		t.Errorf("expected collation latin1_bin, found %q", collation)
+	}
+
+	stmt, err := SQLParser.NewParser(strings.NewReader("ALTER TABLE t CONVERT TO CHARACTER SET utf8mb4 COLLATE 'utf8mb4_bin'")).ParseStatement()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if collation := stmt.(*SQLParser.AlterTableStatement).Specs[0].Collation; collation != "utf8mb4_bin" {
+		t.Errorf("expected CONVERT collation utf8mb4_bin, found %q", collation)
+	}
+}
+
+func Test_Charset_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TABLE t (a char(1) CHARACTER utf8);", err: `found "utf8", expected CHARACTER SET`},
+		{s: "CREATE TABLE t (a char(1) COLLATE);", err: `found ")", expected collation`},
+		{s: "CREATE TABLE t (a char(1) COLLATE 5);", err: `found "5", expected collation`},
+		{s: "CREATE TABLE t (a char(1) ASCII);", err: `found "ASCII", expected column constraint`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewParser(strings.NewReader(tt.s)).Parse()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Check_Parser(t *testing.T) {
+	s := "CREATE TABLE `item` (\n" +
+		"  `price` decimal(10,2) NOT NULL CHECK (price >= 0),\n" +
+		"  `qty` int CHECK (qty > 0) NOT ENFORCED NOT NULL,\n" +
+		"  `total` decimal(10,2) AS (price * qty) STORED NOT NULL,\n" +
+		"  `label` varchar(32) GENERATED ALWAYS AS (concat('#', qty)) VIRTUAL,\n" +
+		"  CONSTRAINT `chk_total` CHECK (total < 1000000) ENFORCED,\n" +
+		"  CHECK (price <> 0 OR qty = 0) NOT ENFORCED\n" +
+		");"
+
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := schema["item"]
+
+	checks := []*SQLParser.CheckConstraint{
+		{
+			Name:     "item_chk_1",
+			Expr:     &SQLParser.BinaryExpr{Op: SQLParser.GTE, LHS: &SQLParser.Ident{Name: "price"}, RHS: &SQLParser.NumberLit{Value: "0"}},
+			Enforced: true,
+		},
+		{
+			Name: "item_chk_2",
+			Expr: &SQLParser.BinaryExpr{Op: SQLParser.GT, LHS: &SQLParser.Ident{Name: "qty"}, RHS: &SQLParser.NumberLit{Value: "0"}},
+		},
+		{
+			Name:     "chk_total",
+			Expr:     &SQLParser.BinaryExpr{Op: SQLParser.LT, LHS: &SQLParser.Ident{Name: "total"}, RHS: &SQLParser.NumberLit{Value: "1000000"}},
+			Enforced: true,
+		},
+	}
+	if !reflect.DeepEqual(checks, item.CheckList[:3]) {
+		t.Errorf("checks mismatch:\n  exp=%#v\n  got=%#v", checks, item.CheckList)
+	}
+	if len(item.CheckList) != 4 || item.Checks["item_chk_3"] != item.CheckList[3] {
+		t.Fatalf("expected a fourth check item_chk_3, found %v", item.Checks)
+	}
+	if last := item.CheckList[3]; last.Enforced || last.Expr.String() != "price <> 0 OR qty = 0" {
+		t.Errorf("unexpected check %s enforced=%v", last.Expr, last.Enforced)
+	}
+
+	if qty := item.Columns["qty"]; qty.Nullable {
+		t.Errorf("expected qty to be NOT NULL")
+	}
+
+	total := item.Columns["total"]
+	expr := &SQLParser.BinaryExpr{Op: SQLParser.ASTERISK, LHS: &SQLParser.Ident{Name: "price"}, RHS: &SQLParser.Ident{Name: "qty"}}
+	if !reflect.DeepEqual(expr, total.Generated) || !total.Stored || total.Nullable {
+		t.Errorf("unexpected generated column total: %s stored=%v", total.Generated, total.Stored)
+	}
+
+	label := item.Columns["label"]
+	if label.Generated == nil || label.Generated.String() != "CONCAT('#', qty)" || label.Stored {
+		t.Errorf("unexpected generated column label: %s stored=%v", label.Generated, label.Stored)
+	}
+}
+
+func Test_Check_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TABLE t (a int, CHECK a > 0);", err: `found "a", expected (`},
+		{s: "CREATE TABLE t (a int, CHECK (a > 0) NOT NULL);", err: `found "NULL", expected NOT ENFORCED`},
+		{s: "CREATE TABLE t (a int, b int GENERATED AS (a));", err: `found "AS (", expected GENERATED ALWAYS AS`},
+		{s: "CREATE TABLE t (a int, b int AS (a b));", err: `found "b", expected )`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewParser(strings.NewReader(tt.s)).Parse()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_CreateIndex_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{
+			s: `CREATE INDEX idx_ab ON t (a, b DESC)`,
+			stmt: &SQLParser.CreateIndexStatement{Table: "t", Index: &SQLParser.Index{
+				Name: "idx_ab", Kind: "KEY",
+				Columns: []*SQLParser.IndexColumn{{Name: "a"}, {Name: "b", Desc: true}},
+			}},
+		},
+		{
+			s: "CREATE UNIQUE INDEX IF NOT EXISTS `uk_email` ON `user` (`email`(20))",
+			stmt: &SQLParser.CreateIndexStatement{Table: "user", IfNotExists: true, Index: &SQLParser.Index{
+				Name: "uk_email", Kind: "UNIQUE", Columns: []*SQLParser.IndexColumn{{Name: "email", Length: 20}},
+			}},
+		},
+		{
+			s: `CREATE FULLTEXT INDEX ft USING BTREE ON post (body) COMMENT 'search'`,
+			stmt: &SQLParser.CreateIndexStatement{Table: "post", Index: &SQLParser.Index{
+				Name: "ft", Kind: "FULLTEXT", Using: "BTREE", Comment: "search",
+				Columns: []*SQLParser.IndexColumn{{Name: "body"}},
+			}},
+		},
+		{
+			s: `CREATE INDEX CONCURRENTLY ON t USING HASH (a) WHERE a > 0`,
+			stmt: &SQLParser.CreateIndexStatement{Table: "t",
+				Index: &SQLParser.Index{Kind: "KEY", Using: "HASH", Columns: []*SQLParser.IndexColumn{{Name: "a"}}},
+				Where: &SQLParser.BinaryExpr{Op: SQLParser.GT, LHS: &SQLParser.Ident{Name: "a"}, RHS: &SQLParser.NumberLit{Value: "0"}},
+			},
+		},
+		{s: "DROP INDEX `idx` ON `t`", stmt: &SQLParser.DropIndexStatement{Name: "idx", Table: "t"}},
+		{s: `DROP INDEX IF EXISTS idx CASCADE`, stmt: &SQLParser.DropIndexStatement{Name: "idx", IfExists: true}},
+
+		// Errors
+		{s: `CREATE UNIQUE KEY k ON t (a)`, err: `found "KEY", expected INDEX`},
+		{s: `CREATE INDEX idx t (a)`, err: `found "t", expected ON`},
+		{s: `CREATE INDEX idx ON t`, err: `found "EOF", expected (`},
+		{s: `DROP INDEX ON t`, err: `found "ON", expected index name`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
+
+func Test_CreateIndex_SchemaParser(t *testing.T) {
+	s := "CREATE TABLE user (\n  id int NOT NULL,\n  email varchar(50),\n  name varchar(20),\n  PRIMARY KEY (id)\n);\n" +
+		"CREATE UNIQUE INDEX uk_email ON user (email);\n" +
+		"CREATE INDEX ON user (name);\n" +
+		"CREATE INDEX idx_tmp ON user (name, email);\n" +
+		"CREATE INDEX IF NOT EXISTS uk_email ON user (name);\n" +
+		"DROP INDEX idx_tmp;"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := schema["user"]
+	if index := table.UniqueKeys["uk_email"]; index == nil || index.Columns[0].Name != "email" {
+		t.Errorf("expected unique key uk_email on email, found %#v", index)
+	}
+	if index := table.Keys["name"]; index == nil {
+		t.Errorf("expected unnamed index to be named name, found %v", table.Keys)
+	}
+	if table.Keys["idx_tmp"] != nil || len(table.IndexList) != 3 {
+		t.Errorf("expected idx_tmp to be dropped, found %d indexes", len(table.IndexList))
+	}
+}
+
+func Test_CreateIndex_SchemaErrors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: `CREATE INDEX i ON missing (id)`, err: `cannot create index on "missing", table does not exist`},
+		{s: `CREATE INDEX i ON a (x)`, err: `cannot create index on "a", column "x" does not exist`},
+		{s: `CREATE INDEX k ON a (id)`, err: `cannot create index "k", it already exists`},
+		{s: `DROP INDEX i`, err: `cannot drop index "i", it does not exist`},
+		{s: `DROP INDEX i ON missing`, err: `cannot drop index "i", table "missing" does not exist`},
+		{s: `DROP INDEX IF EXISTS i`},
+	}
+
+	for i, tt := range tests {
+		s := "CREATE TABLE a (id int, KEY k (id));\n" + tt.s
+		_, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_CreateTable_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s           string
+		temporary   bool
+		ifNotExists bool
+		like        string
+		duplicates  string
+		asSelect    *SQLParser.SelectStatement
+		columns     int
+	}{
+		{s: "CREATE TABLE t (a int)", columns: 1},
+		{s: "CREATE TEMPORARY TABLE IF NOT EXISTS t (a int, b int);", temporary: true, ifNotExists: true, columns: 2},
+		{s: "CREATE TABLE t2 LIKE t1", like: "t1"},
+		{s: "CREATE TABLE IF NOT EXISTS t2 (LIKE t1)", ifNotExists: true, like: "t1"},
+		{
+			s:        "CREATE TABLE t AS SELECT id, name FROM user",
+			asSelect: &SQLParser.SelectStatement{
+				Fields:    []string{"id", "name"},
+				Exprs:     []SQLParser.Expr{&SQLParser.Ident{Name: "id"}, &SQLParser.Ident{Name: "name"}},
+				TableName: "user",
+			},
+		},
+		{
+			s:          "CREATE TABLE t (id int) ENGINE=InnoDB REPLACE SELECT id FROM user",
+			duplicates: "REPLACE",
+			asSelect:   &SQLParser.SelectStatement{Fields: []string{"id"}, Exprs: []SQLParser.Expr{&SQLParser.Ident{Name: "id"}}, TableName: "user"},
+			columns:    1,
+		},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if err != nil {
+			t.Errorf("%d. %q: %v", i, tt.s, err)
+			continue
+		}
+		create, ok := stmt.(*SQLParser.CreateTableStatement)
+		if !ok {
+			t.Errorf("%d. %q: unexpected statement %#v", i, tt.s, stmt)
+			continue
+		}
+		if create.Temporary != tt.temporary || create.Table.Temporary != tt.temporary || create.IfNotExists != tt.ifNotExists ||
+			create.Like != tt.like || create.Duplicates != tt.duplicates {
+			t.Errorf("%d. %q: statement mismatch: %#v", i, tt.s, create)
+		}
+		if !reflect.DeepEqual(tt.asSelect, create.Table.AsSelect) {
+			t.Errorf("%d. %q: select mismatch:\n  exp=%#v\n  got=%#v", i, tt.s, tt.asSelect, create.Table.AsSelect)
+		}
+		if len(create.Table.ColumnList) != tt.columns {
+			t.Errorf("%d. %q: expected %d columns, found %d", i, tt.s, tt.columns, len(create.Table.ColumnList))
+		}
+	}
+}
+
+func Test_CreateTable_SchemaParser(t *testing.T) {
+	s := "CREATE TABLE `user` (\n" +
+		"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+		"  `team_id` int REFERENCES team (id),\n" +
+		"  `age` int CHECK (age > 0),\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `idx_team` (`team_id`)\n" +
+		") ENGINE=InnoDB AUTO_INCREMENT=42 DEFAULT CHARSET=utf8mb4;\n" +
+		"CREATE TEMPORARY TABLE IF NOT EXISTS `user_copy` LIKE `user`;\n" +
+		"CREATE TABLE IF NOT EXISTS `user` (`other` int);\n" +
+		"CREATE TABLE `report` AS SELECT id FROM user;"
+
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := []string{"report", "user", "user_copy"}, schema.TableNames(); !reflect.DeepEqual(exp, got) {
+		t.Fatalf("tables mismatch: exp=%v got=%v", exp, got)
+	}
+
+	user, copy := schema["user"], schema["user_copy"]
+	if user.Columns["other"] != nil {
+		t.Errorf("expected CREATE TABLE IF NOT EXISTS to keep the existing table")
+	}
+	if !copy.Temporary || copy.Name != "user_copy" || copy.Engine != "InnoDB" || copy.AutoIncrement != 0 {
+		t.Errorf("unexpected copy %#v", copy)
+	}
+	if len(copy.ColumnList) != 3 || copy.Columns["id"] == user.Columns["id"] || copy.ColumnList[0] != copy.Columns["id"] {
+		t.Errorf("expected the columns to be copied, found %v", copy.ColumnList)
+	}
+	if copy.PrimaryKey == nil || copy.Keys["idx_team"] == nil || copy.Keys["idx_team"] == user.Keys["idx_team"] {
+		t.Errorf("expected the indexes to be copied, found %v %v", copy.PrimaryKey, copy.Keys)
+	}
+	if len(copy.Constraints) != 0 {
+		t.Errorf("expected foreign keys not to be copied, found %v", copy.Constraints)
+	}
+	if copy.Checks["user_copy_chk_1"] == nil {
+		t.Errorf("expected check user_copy_chk_1, found %v", copy.Checks)
+	}
+	if schema["report"].AsSelect == nil {
+		t.Errorf("expected report to keep its query")
+	}
+
+	_, err = SQLParser.NewParser(strings.NewReader("CREATE TABLE a LIKE b;")).Parse()
+	if errstring(err) != `cannot create "a" like "b", table does not exist` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func Test_CreateTable_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TEMPORARY VIEW v", err: `found "VIEW", expected TABLE`},
+		{s: "CREATE TABLE IF EXISTS t (a int)", err: `found "EXISTS t", expected NOT EXISTS`},
+		{s: "CREATE TABLE t (LIKE a b)", err: `found "b", expected )`},
+		{s: "CREATE TABLE t (a int) AS UPDATE", err: `found "UPDATE", expected SELECT`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
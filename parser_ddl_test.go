@@ -0,0 +1,72 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_DDL_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{s: "DROP TABLE `user`", stmt: &SQLParser.DropTableStatement{Tables: []string{"user"}}},
+		{
+			s:    `DROP TEMPORARY TABLE IF EXISTS a, b CASCADE;`,
+			stmt: &SQLParser.DropTableStatement{Temporary: true, IfExists: true, Tables: []string{"a", "b"}, Cascade: true},
+		},
+		{s: `DROP TABLE a RESTRICT`, stmt: &SQLParser.DropTableStatement{Tables: []string{"a"}, Restrict: true}},
+		{s: `TRUNCATE TABLE logs`, stmt: &SQLParser.TruncateStatement{Table: "logs"}},
+		{s: `TRUNCATE logs`, stmt: &SQLParser.TruncateStatement{Table: "logs"}},
+		{
+			s: `RENAME TABLE a TO b, b TO c`,
+			stmt: &SQLParser.RenameTableStatement{Tables: []*SQLParser.TableRename{
+				{From: "a", To: "b"},
+				{From: "b", To: "c"},
+			}},
+		},
+
+		// Errors
+		{s: `DROP TABLE IF a`, err: `found "a", expected EXISTS`},
+		{s: `DROP TABLE a,`, err: `found "EOF", expected table name`},
+		{s: `DROP TEMPORARY a`, err: `found "a", expected TABLE`},
+		{s: `DROP EVENT e`, err: `found DROP "EVENT", expected DROP TABLE, INDEX, VIEW, TRIGGER, PROCEDURE, FUNCTION, DATABASE, USER or ROLE`},
+		{s: `RENAME TABLE a b`, err: `found "b", expected TO`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
+
+func Test_DDL_SchemaParser(t *testing.T) {
+	s := "CREATE TABLE a (\n  `id` int NOT NULL\n);\nCREATE TABLE b (\n  `id` int NOT NULL\n);\n" +
+		"CREATE TABLE c (\n  `id` int NOT NULL\n);\nDROP DATABASE IF EXISTS other;\nTRUNCATE c;\n" +
+		"DROP TABLE IF EXISTS a, missing;\nRENAME TABLE b TO archive;"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schema) != 2 {
+		t.Errorf("expected 2 tables, found %d", len(schema))
+	}
+	if schema["a"] != nil || schema["b"] != nil {
+		t.Errorf("expected tables a and b to be gone")
+	}
+	if archive := schema["archive"]; archive == nil || archive.Name != "archive" {
+		t.Errorf("expected table b to be renamed to archive, found %v", archive)
+	}
+
+	_, err = SQLParser.NewParser(strings.NewReader("RENAME TABLE a TO b;")).Parse()
+	if errstring(err) != `cannot rename "a", table does not exist` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Default_Parser(t *testing.T) {
+	var tests = []struct {
+		s        string
+		def      interface{}
+		onUpdate SQLParser.Expr
+		nullable bool
+	}{
+		{s: "a int", def: nil},
+		{s: "a int DEFAULT NULL", def: &SQLParser.NullLit{}, nullable: true},
+		{s: "a int NOT NULL DEFAULT 0", def: &SQLParser.NumberLit{Value: "0"}},
+		{s: "a int NULL DEFAULT 0", def: &SQLParser.NumberLit{Value: "0"}, nullable: true},
+		{
+			s:        "a timestamp(6) NULL DEFAULT CURRENT_TIMESTAMP(6)",
+			def:      &SQLParser.Call{Name: "CURRENT_TIMESTAMP", Args: []SQLParser.Expr{&SQLParser.NumberLit{Value: "6"}}},
+			nullable: true,
+		},
+		{s: "a int DEFAULT -1", def: &SQLParser.NumberLit{Value: "-1"}},
+		{s: "a decimal(10,2) DEFAULT 9.99", def: &SQLParser.NumberLit{Value: "9.99"}},
+		{s: "a varchar(8) DEFAULT 'x'", def: &SQLParser.StringLit{Value: "x"}},
+		{s: "a bit(1) DEFAULT b'0'", def: &SQLParser.BitLit{Value: "0"}},
+		{s: "a boolean DEFAULT TRUE", def: &SQLParser.BoolLit{Value: true}},
+		{s: "a timestamp DEFAULT CURRENT_TIMESTAMP", def: &SQLParser.Call{Name: "CURRENT_TIMESTAMP"}},
+		{
+			s:        "a datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6)",
+			def:      &SQLParser.Call{Name: "CURRENT_TIMESTAMP", Args: []SQLParser.Expr{&SQLParser.NumberLit{Value: "6"}}},
+			onUpdate: &SQLParser.Call{Name: "CURRENT_TIMESTAMP", Args: []SQLParser.Expr{&SQLParser.NumberLit{Value: "6"}}},
+		},
+		{
+			s:        "a timestamp NULL ON UPDATE CURRENT_TIMESTAMP",
+			onUpdate: &SQLParser.Call{Name: "CURRENT_TIMESTAMP"},
+			nullable: true,
+		},
+		{s: "a binary(16) DEFAULT (uuid())", def: &SQLParser.ParenExpr{X: &SQLParser.Call{Name: "UUID"}}},
+		{
+			s: "a int DEFAULT (b + 1)",
+			def: &SQLParser.ParenExpr{X: &SQLParser.BinaryExpr{
+				Op:  SQLParser.PLUS,
+				LHS: &SQLParser.Ident{Name: "b"},
+				RHS: &SQLParser.NumberLit{Value: "1"},
+			}},
+		},
+	}
+
+	for i, tt := range tests {
+		s := "CREATE TABLE t (" + tt.s + ");"
+		schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+		if err != nil {
+			t.Errorf("%d. %q: %v", i, tt.s, err)
+			continue
+		}
+		column := schema["t"].Columns["a"]
+		if !reflect.DeepEqual(tt.def, column.Default) {
+			t.Errorf("%d. %q: default mismatch:\n  exp=%#v\n  got=%#v", i, tt.s, tt.def, column.Default)
+		}
+		if !reflect.DeepEqual(tt.onUpdate, column.OnUpdate) {
+			t.Errorf("%d. %q: on update mismatch:\n  exp=%#v\n  got=%#v", i, tt.s, tt.onUpdate, column.OnUpdate)
+		}
+		if tt.nullable != column.Nullable {
+			t.Errorf("%d. %q: expected nullable %v", i, tt.s, tt.nullable)
+		}
+	}
+}
+
+func Test_Default_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TABLE t (a int DEFAULT);", err: `found ")", expected expression`},
+		{s: "CREATE TABLE t (a timestamp ON DELETE CURRENT_TIMESTAMP);", err: `found "DELETE", expected ON UPDATE`},
+		{s: "CREATE TABLE t (a bit(2) DEFAULT b'02');", err: `found "b'02", expected expression`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewParser(strings.NewReader(tt.s)).Parse()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+// uuidDialect is MySQL with a uuid type and level as a reserved word.
+type uuidDialect struct {
+	SQLParser.Dialect
+}
+
+func (d uuidDialect) Type(name string) (string, bool) {
+	if name == "uuid" {
+		return "binary", true
+	}
+	return d.Dialect.Type(name)
+}
+
+func (d uuidDialect) Reserved(tok SQLParser.Tokens) bool {
+	return tok == SQLParser.LEVEL || d.Dialect.Reserved(tok)
+}
+
+// bracketDialect is PostgreSQL quoting identifiers with [...] too.
+type bracketDialect struct {
+	SQLParser.Dialect
+}
+
+func (d bracketDialect) IdentQuote(ch rune) rune {
+	if ch == '[' {
+		return ']'
+	}
+	return d.Dialect.IdentQuote(ch)
+}
+
+// renamedDialect is PostgreSQL under another name, read with the grammar of
+// MySQL.
+type renamedDialect struct {
+	SQLParser.Dialect
+}
+
+func (d renamedDialect) Name() string { return "renamed" }
+
+// grammar has the grammar switches of the built-in dialects, which a Dialect
+// may have to be read with another grammar than MySQL's.
+type grammar interface {
+	DollarQuotes() bool
+	TypeSyntax() SQLParser.TypeSyntax
+	IndexExpressions() bool
+	IndexCollation() bool
+	ConflictClauses() bool
+	RowidTables() bool
+	NamedPrimaryKeys() bool
+	TriggerSyntax() SQLParser.TriggerSyntax
+	SkipsStatement(word string) bool
+	SkipsUnknownObjects() bool
+}
+
+// dollarDialect is MySQL with $$dollar$$ quoted strings.
+type dollarDialect struct {
+	SQLParser.Dialect
+	grammar
+}
+
+func (d dollarDialect) DollarQuotes() bool { return true }
+
+// cockroachDialect is PostgreSQL under another name, with its grammar.
+type cockroachDialect struct {
+	SQLParser.Dialect
+	grammar
+}
+
+func (d cockroachDialect) Name() string { return "cockroachdb" }
+
+func (d cockroachDialect) SkipsStatement(word string) bool {
+	return word == "UPSERT" || d.grammar.SkipsStatement(word)
+}
+
+var (
+	dollar    = dollarDialect{SQLParser.MySQL, SQLParser.MySQL.(grammar)}
+	cockroach = cockroachDialect{SQLParser.PostgreSQL, SQLParser.PostgreSQL.(grammar)}
+)
+
+func Test_Dialect_Parser(t *testing.T) {
+	var tests = []struct {
+		dialect SQLParser.Dialect
+		s       string
+		column  *SQLParser.Column
+		err     string
+	}{
+		{dialect: SQLParser.MySQL, s: "`a` real", column: &SQLParser.Column{Type: "double"}},
+		{dialect: SQLParser.MySQL, s: `a varchar(5) DEFAULT "x\ty"`, column: &SQLParser.Column{Type: "varchar", Size: 5, Default: &SQLParser.StringLit{Value: "x\ty"}}},
+		{dialect: SQLParser.MySQL, s: "a int DEFAULT 1::int", err: `found "::", expected column constraint`},
+		{dialect: SQLParser.PostgreSQL, s: `"a" real DEFAULT 1::int`, column: &SQLParser.Column{Type: "float", Default: &SQLParser.CastExpr{X: &SQLParser.NumberLit{Value: "1"}, Type: "int"}}},
+		{dialect: SQLParser.PostgreSQL, s: "`a` int", err: "found \"`\", expected ident or primary or unique or key or constraint"},
+
+		{dialect: SQLParser.ANSI, s: `"a" real`, column: &SQLParser.Column{Type: "float"}},
+		{dialect: SQLParser.ANSI, s: `a character varying(8) DEFAULT 'x\y'`, column: &SQLParser.Column{Type: "varchar", Size: 8, Default: &SQLParser.StringLit{Value: `x\y`}}},
+		{dialect: SQLParser.ANSI, s: "a clob", column: &SQLParser.Column{Type: "text"}},
+		{dialect: SQLParser.ANSI, s: "a double precision", column: &SQLParser.Column{Type: "double"}},
+		{dialect: SQLParser.ANSI, s: "a tinyint", err: `found "tinyint", expected type`},
+		{dialect: SQLParser.ANSI, s: "a json", err: `found "json", expected type`},
+		{dialect: SQLParser.ANSI, s: "a int DEFAULT 1::int", err: `found "::", expected column constraint`},
+		{dialect: SQLParser.ANSI, s: "`a` int", err: "found \"`\", expected ident or primary or unique or key or constraint"},
+
+		{dialect: uuidDialect{SQLParser.MySQL}, s: "id uuid(16)", column: &SQLParser.Column{Type: "binary", Size: 16}},
+		{dialect: uuidDialect{SQLParser.MySQL}, s: "level int", err: `found "level", expected ident or primary or unique or key or constraint`},
+		{dialect: SQLParser.MySQL, s: "id uuid(16)", err: `found "uuid", expected type`},
+		{dialect: bracketDialect{SQLParser.PostgreSQL}, s: "[id] serial", column: &SQLParser.Column{Type: "int", AutoIncr: true}},
+		{dialect: bracketDialect{SQLParser.PostgreSQL}, s: "[key] int8", column: &SQLParser.Column{Type: "bigint"}},
+		{dialect: dollar, s: "a text DEFAULT $$x$$", column: &SQLParser.Column{Type: "text", Default: &SQLParser.StringLit{Value: "x"}}},
+		{dialect: SQLParser.MySQL, s: "a text DEFAULT $$x$$", err: `found "$", expected expression`},
+		{dialect: cockroach, s: `"a" timestamp with time zone DEFAULT $$x$$`, column: &SQLParser.Column{Type: "timestamp", TimeZone: true, Default: &SQLParser.StringLit{Value: "x"}}},
+
+		{dialect: bracketDialect{SQLParser.PostgreSQL}, s: `[a] text DEFAULT $$x$$`, column: &SQLParser.Column{Type: "text", Default: &SQLParser.StringLit{Value: "x"}}},
+		{dialect: renamedDialect{SQLParser.PostgreSQL}, s: `"a" text DEFAULT $$x$$`, err: `found "$", expected expression`},
+		{dialect: SQLParser.PostgreSQL, s: "a int unsigned", err: `found "unsigned", expected column constraint`},
+		{dialect: SQLParser.PostgreSQL, s: "key int", column: &SQLParser.Column{Type: "int"}},
+		{dialect: SQLParser.PostgreSQL, s: "read boolean", column: &SQLParser.Column{Type: "boolean"}},
+		{dialect: SQLParser.PostgreSQL, s: "write text, lock int", column: &SQLParser.Column{Type: "text"}},
+		{dialect: SQLParser.SQLite, s: "read boolean", column: &SQLParser.Column{Type: "boolean", Affinity: "NUMERIC"}},
+		{dialect: SQLParser.SQLite, s: "write text, show int", column: &SQLParser.Column{Type: "text", Affinity: "TEXT"}},
+		{dialect: SQLParser.MySQL, s: "read int", err: `found "read", expected ident or primary or unique or key or constraint`},
+		{dialect: SQLParser.SQLite, s: "a integer AUTOINCREMENT", column: &SQLParser.Column{Type: "int", AutoIncr: true, Affinity: "INTEGER"}},
+		{dialect: SQLParser.SQLite, s: "a int AUTO_INCREMENT", column: &SQLParser.Column{Type: "int auto_increment", Affinity: "INTEGER"}},
+	}
+
+	for i, tt := range tests {
+		s := "CREATE TABLE t (" + tt.s + ");"
+		schema, err := SQLParser.NewParser(strings.NewReader(s), SQLParser.WithDialect(tt.dialect)).Parse()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %s %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.dialect.Name(), tt.s, tt.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		column := schema["t"].ColumnList[0]
+		tt.column.Name = column.Name
+		tt.column.Nullable = column.Nullable
+		if !reflect.DeepEqual(tt.column, column) {
+			t.Errorf("%d. %s %q\n\ncolumn mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.dialect.Name(), tt.s, tt.column, column)
+		}
+	}
+}
+
+func Test_Dialect_Options(t *testing.T) {
+	s := "CREATE TABLE t (a int) /*!50100 COMMENT='versioned' */;"
+
+	schema, err := SQLParser.NewParser(strings.NewReader(s), SQLParser.WithServerVersion(80036)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment := schema["t"].Comment; comment != "versioned" {
+		t.Errorf("expected the versioned comment executed, found %q", comment)
+	}
+
+	if typ := SQLParser.Type[SQLParser.INTEGER]; typ != "int" {
+		t.Errorf("expected Type to name integer int, found %q", typ)
+	}
+
+	p := SQLParser.NewParser(strings.NewReader(`CREATE TABLE "t" (a int);`), SQLParser.WithDialect(SQLParser.PostgreSQL))
+	p.SetDialect(SQLParser.MySQL)
+	if _, err := p.Parse(); errstring(err) != `found "t", expected table name` {
+		t.Errorf("expected SetDialect to replace the option, found %v", err)
+	}
+}
+
+func Test_Dialect_Grammar(t *testing.T) {
+	s := "UPSERT INTO t VALUES (1);\nCREATE TEMP TABLE t (a int);\nCREATE TRIGGER tr AFTER INSERT ON t EXECUTE FUNCTION f();"
+	schema, err := SQLParser.NewParser(strings.NewReader(s), SQLParser.WithDialect(cockroach)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table := schema["t"]; table == nil || !table.Temporary {
+		t.Errorf("expected the temporary table t, found %#v", table)
+	}
+
+	s = "CREATE TEMP TABLE t (a int);"
+	if _, err := SQLParser.NewParser(strings.NewReader(s)).Parse(); errstring(err) == "" {
+		t.Errorf("expected TEMP to be no keyword of MySQL")
+	}
+}
@@ -0,0 +1,169 @@
+package SQLParser_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+const dump = "-- MySQL dump 10.13  Distrib 8.0.36, for Linux (x86_64)\n" +
+	"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;\n" +
+	"/*!50503 SET NAMES utf8mb4 */;\n" +
+	"/*!40103 SET TIME_ZONE='+00:00' */;\n" +
+	"/*!40014 SET @OLD_UNIQUE_CHECKS=@@UNIQUE_CHECKS, UNIQUE_CHECKS=0 */;\n" +
+	"SET @@GLOBAL.GTID_PURGED=/*!80000 '+'*/ '3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5';\n" +
+	"\n" +
+	"DROP TABLE IF EXISTS `user`;\n" +
+	"/*!40101 SET @saved_cs_client     = @@character_set_client */;\n" +
+	"CREATE TABLE `user` (\n" +
+	"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+	"  `name` varchar(20) DEFAULT NULL,\n" +
+	"  `data` blob,\n" +
+	"  PRIMARY KEY (`id`)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n" +
+	"/*!40101 SET character_set_client = @saved_cs_client */;\n" +
+	"\n" +
+	"LOCK TABLES `user` WRITE;\n" +
+	"/*!40000 ALTER TABLE `user` DISABLE KEYS */;\n" +
+	"INSERT INTO `user` VALUES (1,'bob',0x0A0B),(2,'it\\'s;',_binary 'ab'),(3,NULL,NULL);\n" +
+	"/*!40000 ALTER TABLE `user` ENABLE KEYS */;\n" +
+	"UNLOCK TABLES;\n" +
+	"\n" +
+	"CREATE TABLE `score` (`user_id` int, `value` float);\n" +
+	"INSERT INTO `score` (`value`, `user_id`) VALUES (1e-05,1);\n" +
+	"/*!40014 SET UNIQUE_CHECKS=@OLD_UNIQUE_CHECKS */;\n" +
+	"-- Dump completed\n"
+
+func Test_Dump_Reader(t *testing.T) {
+	var rows []*SQLParser.Row
+	schema, err := SQLParser.NewDumpReader(strings.NewReader(dump)).Read(func(row *SQLParser.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if names := schema.TableNames(); !reflect.DeepEqual(names, []string{"score", "user"}) {
+		t.Errorf("expected tables [score user], found %v", names)
+	}
+	if user := schema["user"]; user == nil || len(user.ColumnList) != 3 || !user.Columns["id"].AutoIncr {
+		t.Errorf("user table mismatch: %#v", user)
+	}
+
+	userColumns := []string{"id", "name", "data"}
+	expected := []*SQLParser.Row{
+		{Table: "user", Columns: userColumns, Values: []SQLParser.Expr{
+			&SQLParser.NumberLit{Value: "1"}, &SQLParser.StringLit{Value: "bob"}, &SQLParser.HexLit{Value: "0A0B"},
+		}},
+		{Table: "user", Columns: userColumns, Values: []SQLParser.Expr{
+			&SQLParser.NumberLit{Value: "2"}, &SQLParser.StringLit{Value: "it's;"}, &SQLParser.StringLit{Value: "ab", Charset: "binary"},
+		}},
+		{Table: "user", Columns: userColumns, Values: []SQLParser.Expr{
+			&SQLParser.NumberLit{Value: "3"}, &SQLParser.NullLit{}, &SQLParser.NullLit{},
+		}},
+		{Table: "score", Columns: []string{"value", "user_id"}, Values: []SQLParser.Expr{
+			&SQLParser.NumberLit{Value: "1e-05"}, &SQLParser.NumberLit{Value: "1"},
+		}},
+	}
+	if !reflect.DeepEqual(expected, rows) {
+		t.Errorf("rows mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", expected, rows)
+	}
+}
+
+func Test_Dump_ReaderErrors(t *testing.T) {
+	stop := errors.New("stop")
+	var rows int
+	schema, err := SQLParser.NewDumpReader(strings.NewReader(dump)).Read(func(row *SQLParser.Row) error {
+		if rows++; rows == 2 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("expected the callback error, found %v", err)
+	}
+	if rows != 2 || schema["user"] == nil || schema["score"] != nil {
+		t.Errorf("expected reading to stop at the second row, read %d rows and tables %v", rows, schema.TableNames())
+	}
+
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TABLE t (a int, b int);\nINSERT INTO t VALUES (1,2),(3);", err: `found 1 values, expected 2 for "t"`},
+		{s: "INSERT INTO t VALUES (1,2) foo;", err: `found "foo", expected ;`},
+		{s: "LOCK TABLES t;", err: `found ";", expected READ or WRITE`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewDumpReader(strings.NewReader(tt.s)).Read(nil)
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
+
+func Test_Dump_ReaderDatabases(t *testing.T) {
+	s := "CREATE DATABASE /*!32312 IF NOT EXISTS*/ `shop` /*!40100 DEFAULT CHARACTER SET utf8mb4 */ /*!80016 DEFAULT ENCRYPTION='N' */;\n" +
+		"USE `shop`;\n" +
+		"CREATE TABLE `user` (`id` int);\n" +
+		"INSERT INTO `user` VALUES (1);\n" +
+		"CREATE DATABASE /*!32312 IF NOT EXISTS*/ `billing`;\n" +
+		"USE `billing`;\n" +
+		"CREATE TABLE `user` (`id` int, `iban` varchar(34));\n" +
+		"INSERT INTO `user` VALUES (1,'DE89');\n"
+
+	var tables []string
+	schema, err := SQLParser.NewDumpReader(strings.NewReader(s)).Read(func(row *SQLParser.Row) error {
+		tables = append(tables, row.Database+"."+row.Table)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := schema.TableNames(); !reflect.DeepEqual(names, []string{"shop.user", "user"}) {
+		t.Errorf("expected tables [shop.user user], found %v", names)
+	}
+	if user := schema["user"]; user == nil || user.Name != "user" || user.Database != "billing" || len(user.ColumnList) != 2 {
+		t.Errorf("expected user of billing, found %#v", user)
+	}
+	if !reflect.DeepEqual(tables, []string{"shop.user", "billing.user"}) {
+		t.Errorf("expected rows of [shop.user billing.user], found %v", tables)
+	}
+}
+
+func Test_Dump_ReaderVersionedComments(t *testing.T) {
+	s := "CREATE TABLE `user` (`id` int, `updated` datetime);\n" +
+		"/*!50003 SET @saved_sql_mode       = @@sql_mode */ ;\n" +
+		"DELIMITER ;;\n" +
+		"/*!50003 CREATE*/ /*!50017 DEFINER=`root`@`localhost`*/ /*!50003 TRIGGER `touch` BEFORE UPDATE ON `user` FOR EACH ROW BEGIN\n" +
+		"  SET NEW.updated = NOW();\n" +
+		"END */;;\n" +
+		"/*!50003 CREATE*/ /*!50017 DEFINER=`root`@`localhost`*/ /*!50003 TRIGGER `count` AFTER INSERT ON `user` FOR EACH ROW SET @n = @n + 1 */;;\n" +
+		"DELIMITER ;\n" +
+		"/*!50003 SET sql_mode              = @saved_sql_mode */ ;\n" +
+		"/*!50001 CREATE ALGORITHM=UNDEFINED */\n" +
+		"/*!50013 DEFINER=`root`@`localhost` SQL SECURITY DEFINER */\n" +
+		"/*!50001 VIEW `recent` AS select `user`.`id` AS `id` from `user` */;\n"
+
+	schema, err := SQLParser.NewDumpReader(strings.NewReader(s)).Read(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	triggers := schema["user"].Triggers
+	if len(triggers) != 2 {
+		t.Fatalf("expected two triggers on user, found %d", len(triggers))
+	}
+	bodies := map[string]string{"touch": "BEGIN\n  SET NEW.updated = NOW();\nEND", "count": "SET @n = @n + 1"}
+	for _, trigger := range triggers {
+		if exp := bodies[trigger.Name]; trigger.Body.Text != exp {
+			t.Errorf("%s: body mismatch:\n  exp=%q\n  got=%q", trigger.Name, exp, trigger.Body.Text)
+		}
+	}
+	if exp, got := "select `user`.`id` AS `id` from `user`", schema["recent"].View.Definition; got != exp {
+		t.Errorf("view definition mismatch:\n  exp=%q\n  got=%q", exp, got)
+	}
+}
@@ -0,0 +1,112 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_ForeignKey_Parser(t *testing.T) {
+	s := "CREATE TABLE `order_item` (\n" +
+		"  `order_id` bigint(20) NOT NULL,\n" +
+		"  `shop_id` bigint(20) NOT NULL,\n" +
+		"  `product_id` bigint(20) NOT NULL REFERENCES product (id) ON DELETE RESTRICT,\n" +
+		"  `coupon_id` bigint(20),\n" +
+		"  CONSTRAINT `fk_order` FOREIGN KEY (`order_id`, `shop_id`) REFERENCES `sales`.`order` (`id`, `shop_id`)\n" +
+		"    MATCH FULL ON DELETE CASCADE ON UPDATE SET NULL,\n" +
+		"  FOREIGN KEY (`coupon_id`) REFERENCES coupon (id) ON UPDATE NO ACTION ON DELETE SET DEFAULT,\n" +
+		"  CONSTRAINT FOREIGN KEY idx_shop (`shop_id`) REFERENCES shop (id)\n" +
+		");"
+
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]*SQLParser.Constraint{
+		"fk_order": {
+			Index:      "fk_order",
+			ForeignKey: "order_id",
+			TableName:  "order",
+			ColumnName: "id",
+			Columns:    []string{"order_id", "shop_id"},
+			RefSchema:  "sales",
+			RefColumns: []string{"id", "shop_id"},
+			Match:      "FULL",
+			OnDelete:   "CASCADE",
+			OnUpdate:   "SET NULL",
+		},
+		"order_item_ibfk_1": {
+			Index:      "order_item_ibfk_1",
+			ForeignKey: "product_id",
+			TableName:  "product",
+			ColumnName: "id",
+			Columns:    []string{"product_id"},
+			RefColumns: []string{"id"},
+			OnDelete:   "RESTRICT",
+		},
+		"order_item_ibfk_2": {
+			Index:      "order_item_ibfk_2",
+			ForeignKey: "coupon_id",
+			TableName:  "coupon",
+			ColumnName: "id",
+			Columns:    []string{"coupon_id"},
+			RefColumns: []string{"id"},
+			OnDelete:   "SET DEFAULT",
+			OnUpdate:   "NO ACTION",
+		},
+		"idx_shop": {
+			Index:      "idx_shop",
+			ForeignKey: "shop_id",
+			TableName:  "shop",
+			ColumnName: "id",
+			Columns:    []string{"shop_id"},
+			RefColumns: []string{"id"},
+		},
+	}
+
+	if got := schema["order_item"].Constraints; !reflect.DeepEqual(expected, got) {
+		for name, c := range got {
+			t.Logf("%s: %#v", name, c)
+		}
+		t.Errorf("constraints mismatch")
+	}
+}
+
+func Test_ForeignKey_NamedIndexConstraints(t *testing.T) {
+	s := "CREATE TABLE t (\n  a int,\n  b int,\n  CONSTRAINT pk PRIMARY KEY (a),\n  CONSTRAINT uk UNIQUE (b)\n);"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := schema["t"]
+	if table.PrimaryKey == nil || table.PrimaryKey.Name != "PRIMARY" {
+		t.Errorf("expected primary key, found %v", table.PrimaryKey)
+	}
+	if table.UniqueKeys["uk"] == nil {
+		t.Errorf("expected unique key uk, found %v", table.UniqueKeys)
+	}
+}
+
+func Test_ForeignKey_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TABLE t (a int, CONSTRAINT c FOREIGN (a) REFERENCES p (id));", err: `found "FOREIGN(", expected FOREIGN KEY`},
+		{s: "CREATE TABLE t (a int, FOREIGN KEY (a) p (id));", err: `found "p", expected REFERENCES`},
+		{s: "CREATE TABLE t (a int, FOREIGN KEY (a) REFERENCES p id);", err: `found ")", expected type`},
+		{s: "CREATE TABLE t (a int, FOREIGN KEY (a) REFERENCES p (id) ON DELETE NOTHING);", err: `found "NOTHING", expected CASCADE, SET NULL, SET DEFAULT, RESTRICT or NO ACTION`},
+		{s: "CREATE TABLE t (a int, FOREIGN KEY (a) REFERENCES p (id) ON INSERT CASCADE);", err: `found "INSERT", expected ON DELETE or ON UPDATE`},
+		{s: "CREATE TABLE t (a int, FOREIGN KEY (a) REFERENCES p (id) MATCH ALL);", err: `found "ALL", expected FULL, PARTIAL or SIMPLE`},
+		{s: "CREATE TABLE t (a int, CONSTRAINT c CHECKS (a));", err: `found "CHECKS", expected PRIMARY KEY, UNIQUE, FOREIGN KEY or CHECK`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewParser(strings.NewReader(tt.s)).Parse()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
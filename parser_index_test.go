@@ -0,0 +1,158 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Index_Parser(t *testing.T) {
+	s := "CREATE TABLE `post` (\n" +
+		"  `id` bigint(20) NOT NULL AUTO_INCREMENT,\n" +
+		"  `user_id` bigint(20) NOT NULL,\n" +
+		"  `slug` varchar(64) NOT NULL UNIQUE,\n" +
+		"  `title` varchar(255) NOT NULL,\n" +
+		"  `body` varchar(1000),\n" +
+		"  `area` int,\n" +
+		"  PRIMARY KEY (`id`, `user_id`),\n" +
+		"  UNIQUE KEY `uk_user_title` (`user_id`, `title`(10) DESC) USING BTREE COMMENT 'one title per user',\n" +
+		"  UNIQUE (`title`),\n" +
+		"  KEY `idx_title` USING HASH (`title` ASC) KEY_BLOCK_SIZE=8 INVISIBLE,\n" +
+		"  INDEX (`user_id`),\n" +
+		"  FULLTEXT KEY `ft_body` (`body`) WITH PARSER ngram,\n" +
+		"  SPATIAL INDEX (`area`)\n" +
+		") ENGINE=InnoDB;"
+
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := schema["post"]
+	if post == nil {
+		t.Fatal("expected table post, but not found")
+	}
+
+	primary := &SQLParser.Index{Name: "PRIMARY", Kind: "PRIMARY", Columns: []*SQLParser.IndexColumn{{Name: "id"}, {Name: "user_id"}}}
+	if !reflect.DeepEqual(primary, post.PrimaryKey) {
+		t.Errorf("primary key mismatch:\n  exp=%#v\n  got=%#v", primary, post.PrimaryKey)
+	}
+
+	unique := map[string]*SQLParser.Index{
+		"slug": {Name: "slug", Kind: "UNIQUE", Columns: []*SQLParser.IndexColumn{{Name: "slug"}}},
+		"uk_user_title": {
+			Name:    "uk_user_title",
+			Kind:    "UNIQUE",
+			Columns: []*SQLParser.IndexColumn{{Name: "user_id"}, {Name: "title", Length: 10, Desc: true}},
+			Using:   "BTREE",
+			Comment: "one title per user",
+		},
+		"title": {Name: "title", Kind: "UNIQUE", Columns: []*SQLParser.IndexColumn{{Name: "title"}}},
+	}
+	if !reflect.DeepEqual(unique, post.UniqueKeys) {
+		t.Errorf("unique keys mismatch:\n  exp=%#v\n  got=%#v", unique, post.UniqueKeys)
+	}
+
+	keys := map[string]*SQLParser.Index{
+		"idx_title": {
+			Name:         "idx_title",
+			Kind:         "KEY",
+			Columns:      []*SQLParser.IndexColumn{{Name: "title"}},
+			Using:        "HASH",
+			KeyBlockSize: 8,
+			Invisible:    true,
+		},
+		"user_id": {Name: "user_id", Kind: "KEY", Columns: []*SQLParser.IndexColumn{{Name: "user_id"}}},
+		"ft_body": {Name: "ft_body", Kind: "FULLTEXT", Columns: []*SQLParser.IndexColumn{{Name: "body"}}, Parser: "ngram"},
+		"area":    {Name: "area", Kind: "SPATIAL", Columns: []*SQLParser.IndexColumn{{Name: "area"}}},
+	}
+	if !reflect.DeepEqual(keys, post.Keys) {
+		t.Errorf("keys mismatch:\n  exp=%#v\n  got=%#v", keys, post.Keys)
+	}
+}
+
+func Test_Index_GeneratedNames(t *testing.T) {
+	s := "CREATE TABLE t (\n  a int,\n  b int,\n  KEY (a),\n  KEY (a, b),\n  UNIQUE INDEX (a)\n);"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := schema["t"]
+	for _, name := range []string{"a", "a_2"} {
+		if table.Keys[name] == nil {
+			t.Errorf("expected key %q, found %v", name, table.Keys)
+		}
+	}
+	if table.UniqueKeys["a_3"] == nil {
+		t.Errorf("expected unique key a_3, found %v", table.UniqueKeys)
+	}
+}
+
+func Test_Index_PrimaryKeyNames(t *testing.T) {
+	var tests = []struct {
+		dialect SQLParser.Dialect
+		s       string
+		name    string
+	}{
+		{dialect: SQLParser.MySQL, s: "CREATE TABLE t (a int, CONSTRAINT pk PRIMARY KEY (a));", name: "PRIMARY"},
+		{dialect: SQLParser.MySQL, s: "CREATE TABLE t (a int CONSTRAINT pk PRIMARY KEY);", name: "PRIMARY"},
+		{dialect: SQLParser.PostgreSQL, s: "CREATE TABLE t (a int, CONSTRAINT t_pkey PRIMARY KEY (a));", name: "t_pkey"},
+		{dialect: SQLParser.PostgreSQL, s: "CREATE TABLE t (a int CONSTRAINT t_pkey PRIMARY KEY);", name: "t_pkey"},
+		{dialect: SQLParser.PostgreSQL, s: "CREATE TABLE t (a int PRIMARY KEY);", name: "PRIMARY"},
+		{dialect: SQLParser.SQLite, s: "CREATE TABLE t (a int, CONSTRAINT t_pk PRIMARY KEY (a));", name: "t_pk"},
+	}
+
+	for i, tt := range tests {
+		schema, err := SQLParser.NewParser(strings.NewReader(tt.s), SQLParser.WithDialect(tt.dialect)).Parse()
+		if err != nil {
+			t.Errorf("%d. %q: %s", i, tt.s, err)
+			continue
+		}
+		if key := schema["t"].PrimaryKey; key == nil || key.Name != tt.name {
+			t.Errorf("%d. %q: expected primary key %q, found %#v", i, tt.s, tt.name, key)
+		}
+	}
+
+	s := "CREATE TABLE t (a int, CONSTRAINT t_pkey PRIMARY KEY (a));\nALTER TABLE t DROP CONSTRAINT t_pkey;"
+	schema, err := SQLParser.NewParser(strings.NewReader(s), SQLParser.WithDialect(SQLParser.PostgreSQL)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table := schema["t"]; table.PrimaryKey != nil || len(table.IndexList) != 0 {
+		t.Errorf("expected the primary key dropped, found %v", table.IndexList)
+	}
+}
+
+func Test_Index_FunctionalKeyParts(t *testing.T) {
+	s := "CREATE TABLE t (a int, b int, KEY ((a + b)), INDEX ((a * 2)) );"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := schema["t"]
+	for name, expr := range map[string]string{"functional_index": "a + b", "functional_index_2": "a * 2"} {
+		if key := table.Keys[name]; key == nil || key.Columns[0].Expr.String() != expr {
+			t.Errorf("expected key %q on %s, found %v", name, expr, table.Keys)
+		}
+	}
+}
+
+func Test_Index_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TABLE t (a int, PRIMARY (a));", err: `found "PRIMARY(", expected PRIMARY KEY`},
+		{s: "CREATE TABLE t (a int, KEY k a);", err: `found "a", expected (`},
+		{s: "CREATE TABLE t (a int, KEY k (a(x)));", err: `found "x)", expected (integer)`},
+		{s: "CREATE TABLE t (a int, KEY k USING RTREE (a));", err: `found "RTREE", expected BTREE or HASH`},
+		{s: "CREATE TABLE t (a int, KEY k (a b));", err: `found "b", expected , or )`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewParser(strings.NewReader(tt.s)).Parse()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
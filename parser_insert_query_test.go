@@ -20,6 +20,15 @@ func Test_INSERT_QueryParser(t *testing.T) {
 			stmt: &SQLParser.InsertStatement{
 				Fields:    []string{"CustomerName","ContactName","Address","City","PostalCode","Country","Cardinal","Tom B. Erichsen","Skagen 21","Stavanger","4006","Norway"},
 				TableName: "Customers",
+				Columns:   []string{"CustomerName","ContactName","Address","City","PostalCode","Country"},
+				Rows: [][]SQLParser.Expr{{
+					&SQLParser.StringLit{Value: "Cardinal"},
+					&SQLParser.StringLit{Value: "Tom B. Erichsen"},
+					&SQLParser.StringLit{Value: "Skagen 21"},
+					&SQLParser.StringLit{Value: "Stavanger"},
+					&SQLParser.StringLit{Value: "4006"},
+					&SQLParser.StringLit{Value: "Norway"},
+				}},
 			},
 		},
 
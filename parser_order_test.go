@@ -0,0 +1,66 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Order_Table(t *testing.T) {
+	s := "CREATE TABLE t (\n" +
+		"  zeta int,\n" +
+		"  alpha int PRIMARY KEY,\n" +
+		"  mid int,\n" +
+		"  KEY k_mid (mid),\n" +
+		"  UNIQUE KEY a_zeta (zeta),\n" +
+		"  CONSTRAINT z_fk FOREIGN KEY (zeta) REFERENCES other (id),\n" +
+		"  CONSTRAINT a_fk FOREIGN KEY (mid) REFERENCES other (id)\n" +
+		");"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := schema["t"]
+
+	var columns, indexes, constraints []string
+	for _, c := range table.ColumnList {
+		columns = append(columns, c.Name)
+		if table.Columns[c.Name] != c {
+			t.Errorf("column %q differs from its lookup", c.Name)
+		}
+	}
+	for _, i := range table.IndexList {
+		indexes = append(indexes, i.Name)
+	}
+	for _, c := range table.ConstraintList {
+		constraints = append(constraints, c.Index)
+	}
+
+	if exp := []string{"zeta", "alpha", "mid"}; !reflect.DeepEqual(exp, columns) {
+		t.Errorf("column order mismatch: exp=%v got=%v", exp, columns)
+	}
+	if exp := []string{"PRIMARY", "k_mid", "a_zeta"}; !reflect.DeepEqual(exp, indexes) {
+		t.Errorf("index order mismatch: exp=%v got=%v", exp, indexes)
+	}
+	if exp := []string{"z_fk", "a_fk"}; !reflect.DeepEqual(exp, constraints) {
+		t.Errorf("constraint order mismatch: exp=%v got=%v", exp, constraints)
+	}
+}
+
+func Test_Order_Schema(t *testing.T) {
+	s := "CREATE TABLE c (id int);\nCREATE TABLE a (id int);\nCREATE TABLE b (id int);"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp, got := []string{"a", "b", "c"}, schema.TableNames(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("table names mismatch: exp=%v got=%v", exp, got)
+	}
+	for i, table := range schema.Tables() {
+		if table != schema[schema.TableNames()[i]] {
+			t.Errorf("%d. table %q out of order", i, table.Name)
+		}
+	}
+}
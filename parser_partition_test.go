@@ -0,0 +1,125 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func partitionOf(t *testing.T, s string) *SQLParser.Partitioning {
+	schema, err := SQLParser.NewParser(strings.NewReader("CREATE TABLE t (id int, created_at datetime) " + s + ";")).Parse()
+	if err != nil {
+		t.Fatalf("%q: %v", s, err)
+	}
+	return schema["t"].Partitioning
+}
+
+func Test_Partition_Range(t *testing.T) {
+	part := partitionOf(t, "ENGINE=InnoDB AUTO_INCREMENT=100 PARTITION BY RANGE (YEAR(created_at)) (\n"+
+		"  PARTITION p2024 VALUES LESS THAN (2025) ENGINE = InnoDB COMMENT 'last year',\n"+
+		"  PARTITION pmax VALUES LESS THAN MAXVALUE DATA DIRECTORY = '/data'\n)")
+
+	year := &SQLParser.Call{Name: "YEAR", Args: []SQLParser.Expr{&SQLParser.Ident{Name: "created_at"}}}
+	expected := &SQLParser.Partitioning{
+		Method: "RANGE",
+		Expr:   year,
+		Partitions: []*SQLParser.Partition{
+			{
+				Name:     "p2024",
+				LessThan: []SQLParser.Expr{&SQLParser.NumberLit{Value: "2025"}},
+				Options:  map[string]string{"engine": "InnoDB", "comment": "last year"},
+			},
+			{Name: "pmax", MaxValue: true, Options: map[string]string{"data directory": "/data"}},
+		},
+	}
+	if !reflect.DeepEqual(expected, part) {
+		t.Errorf("partitioning mismatch:\n  exp=%#v\n  got=%#v", expected, part)
+	}
+}
+
+func Test_Partition_ListColumns(t *testing.T) {
+	part := partitionOf(t, "PARTITION BY LIST COLUMNS (id, created_at) (PARTITION p0 VALUES IN ((1, '2024-01-01'), (2, NULL)))")
+
+	if part.Method != "LIST" || !reflect.DeepEqual([]string{"id", "created_at"}, part.Columns) || part.Expr != nil {
+		t.Errorf("unexpected partitioning %#v", part)
+	}
+	if in := part.Partitions[0].In; len(in) != 2 || in[0].String() != "(1, '2024-01-01')" || in[1].String() != "(2, NULL)" {
+		t.Errorf("unexpected values %v", in)
+	}
+}
+
+func Test_Partition_HashAndKey(t *testing.T) {
+	part := partitionOf(t, "PARTITION BY LINEAR HASH (id) PARTITIONS 4")
+	expected := &SQLParser.Partitioning{Method: "HASH", Linear: true, Expr: &SQLParser.Ident{Name: "id"}, Count: 4}
+	if !reflect.DeepEqual(expected, part) {
+		t.Errorf("hash partitioning mismatch:\n  exp=%#v\n  got=%#v", expected, part)
+	}
+
+	part = partitionOf(t, "PARTITION BY KEY ALGORITHM=2 () PARTITIONS 2")
+	expected = &SQLParser.Partitioning{Method: "KEY", Algorithm: 2, Count: 2}
+	if !reflect.DeepEqual(expected, part) {
+		t.Errorf("key partitioning mismatch:\n  exp=%#v\n  got=%#v", expected, part)
+	}
+}
+
+func Test_Partition_Subpartitions(t *testing.T) {
+	part := partitionOf(t, "PARTITION BY RANGE (id) SUBPARTITION BY HASH (TO_DAYS(created_at)) SUBPARTITIONS 2 (\n"+
+		"  PARTITION p0 VALUES LESS THAN (100) (SUBPARTITION s0, SUBPARTITION s1 STORAGE ENGINE=InnoDB),\n"+
+		"  PARTITION p1 VALUES LESS THAN (200)\n)")
+
+	if part.Sub == nil || part.Sub.Method != "HASH" || part.Sub.Count != 2 || part.Sub.Expr.String() != "TO_DAYS(created_at)" {
+		t.Fatalf("unexpected subpartitioning %#v", part.Sub)
+	}
+	subs := part.Partitions[0].Subpartitions
+	if len(subs) != 2 || subs[0].Name != "s0" || subs[1].Options["engine"] != "InnoDB" {
+		t.Errorf("unexpected subpartitions %v", subs)
+	}
+	if len(part.Partitions) != 2 || part.Partitions[1].Subpartitions != nil {
+		t.Errorf("unexpected partitions %v", part.Partitions)
+	}
+}
+
+func Test_Partition_CreateTableLike(t *testing.T) {
+	s := "CREATE TABLE t (id int, created_at datetime) PARTITION BY RANGE (id) SUBPARTITION BY HASH (id) SUBPARTITIONS 2 (\n" +
+		"  PARTITION p0 VALUES LESS THAN (10) COMMENT 'low' (SUBPARTITION s0, SUBPARTITION s1),\n" +
+		"  PARTITION pmax VALUES LESS THAN MAXVALUE (SUBPARTITION s2, SUBPARTITION s3)\n);\n" +
+		"CREATE TABLE t2 LIKE t;"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, copy := schema["t"].Partitioning, schema["t2"].Partitioning
+	if !reflect.DeepEqual(source, copy) {
+		t.Fatalf("partitioning mismatch:\n  exp=%#v\n  got=%#v", source, copy)
+	}
+	if copy == source || copy.Sub == source.Sub || copy.Partitions[0] == source.Partitions[0] ||
+		copy.Partitions[0].Subpartitions[0] == source.Partitions[0].Subpartitions[0] {
+		t.Errorf("expected the partitioning to be copied, found it shared")
+	}
+	copy.Partitions[0].Options["comment"] = "changed"
+	if source.Partitions[0].Options["comment"] != "low" {
+		t.Errorf("expected the partition options to be copied, found them shared")
+	}
+}
+
+func Test_Partition_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "PARTITION RANGE (id)", err: `found "RANGE", expected BY`},
+		{s: "PARTITION BY LINEAR RANGE (id)", err: `found "RANGE", expected RANGE, LIST, HASH or KEY`},
+		{s: "PARTITION BY RANGE (id) (PARTITION p0 VALUES FROM (1))", err: `found "FROM (", expected LESS THAN or IN`},
+		{s: "PARTITION BY HASH (id) PARTITIONS x", err: `found "x", expected integer PARTITIONS`},
+		{s: "PARTITION BY RANGE (id) (PARTITION p0 VALUES LESS THAN (1) PARTITION p1)", err: `found "PARTITION", expected , or )`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewParser(strings.NewReader("CREATE TABLE t (id int) " + tt.s + ";")).Parse()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
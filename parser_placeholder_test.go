@@ -0,0 +1,96 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Placeholder_Parser(t *testing.T) {
+	var tests = []struct {
+		s      string
+		params []*SQLParser.Placeholder
+		err    string
+	}{
+		{s: `SELECT * FROM user`},
+		{
+			s: `SELECT name FROM user WHERE id = ? AND (age > ? OR age IS NULL)`,
+			params: []*SQLParser.Placeholder{
+				{Text: "?", Ordinal: 1},
+				{Text: "?", Ordinal: 2},
+			},
+		},
+		{
+			s:      `SELECT ? FROM t WHERE a = ?`,
+			params: []*SQLParser.Placeholder{{Text: "?", Ordinal: 1}, {Text: "?", Ordinal: 2}},
+		},
+		{
+			s:      `SELECT concat(name, ?) AS greeting, * FROM user`,
+			params: []*SQLParser.Placeholder{{Text: "?", Ordinal: 1}},
+		},
+		{
+			s: `INSERT INTO user (id, name) VALUES ($1, $2), ($3, 'x')`,
+			params: []*SQLParser.Placeholder{
+				{Text: "$1", Ordinal: 1},
+				{Text: "$2", Ordinal: 2},
+				{Text: "$3", Ordinal: 3},
+			},
+		},
+		{
+			s: `UPDATE user SET name = :name, age = age + 1 WHERE id = :user_id`,
+			params: []*SQLParser.Placeholder{
+				{Text: ":name", Name: "name"},
+				{Text: ":user_id", Name: "user_id"},
+			},
+		},
+		{
+			s:      `DELETE * FROM user WHERE id IN (?, ?)`,
+			params: []*SQLParser.Placeholder{{Text: "?", Ordinal: 1}, {Text: "?", Ordinal: 2}},
+		},
+		{
+			s:      `EXPLAIN SELECT * FROM user WHERE id BETWEEN ? AND 10`,
+			params: []*SQLParser.Placeholder{{Text: "?", Ordinal: 1}},
+		},
+
+		// Errors
+		{s: `SELECT * FROM user WHERE id = $`, err: `found "$", expected expression`},
+		{s: `SELECT * FROM user WHERE id = :`, err: `found ":", expected expression`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if params := SQLParser.Placeholders(stmt); tt.err == "" && !reflect.DeepEqual(tt.params, params) {
+			t.Errorf("%d. %q\n\nplaceholders mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.params, params)
+		}
+	}
+
+	// ? placeholders are numbered per statement.
+	p := SQLParser.NewParser(strings.NewReader(`SELECT * FROM a WHERE x = ?; SELECT * FROM b WHERE y = ?`))
+	for i := 0; i < 2; i++ {
+		stmt, err := p.ParseStatement()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if params := SQLParser.Placeholders(stmt); len(params) != 1 || params[0].Ordinal != 1 {
+			t.Errorf("%d. expected a single placeholder with ordinal 1, found %v", i, params)
+		}
+	}
+
+	// PostgreSQL casts and arrays hold placeholders too.
+	for i, s := range []string{
+		`SELECT * FROM user WHERE id = $1::int AND b = $2`,
+		`SELECT * FROM user WHERE id = ANY(ARRAY[$1, $2])`,
+	} {
+		stmt, err := SQLParser.NewParser(strings.NewReader(s), SQLParser.WithDialect(SQLParser.PostgreSQL)).ParseStatement()
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := []*SQLParser.Placeholder{{Text: "$1", Ordinal: 1}, {Text: "$2", Ordinal: 2}}
+		if params := SQLParser.Placeholders(stmt); !reflect.DeepEqual(exp, params) {
+			t.Errorf("%d. %q\n\nplaceholders mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, s, exp, params)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Postgres_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{
+			s: "CREATE SEQUENCE IF NOT EXISTS public.users_id_seq AS integer START WITH 10 INCREMENT BY -1 NO MINVALUE MAXVALUE 100 CACHE 5 CYCLE OWNED BY public.users.id",
+			stmt: &SQLParser.CreateSequenceStatement{
+				IfNotExists: true,
+				Sequence: &SQLParser.Sequence{
+					Name: "public.users_id_seq", Type: "int", Start: 10, Increment: -1,
+					MaxValue: int64p(100), Cache: 5, Cycle: true, OwnedBy: "public.users.id",
+				},
+			},
+		},
+		{s: "CREATE SEQUENCE s", stmt: &SQLParser.CreateSequenceStatement{Sequence: &SQLParser.Sequence{Name: "s", Type: "bigint", Start: 1, Increment: 1, Cache: 1}}},
+		{
+			s:    "CREATE TYPE public.mood AS ENUM ('sad', 'ok', 'happy')",
+			stmt: &SQLParser.CreateTypeStatement{Name: "public.mood", Kind: "ENUM", Enum: []string{"sad", "ok", "happy"}},
+		},
+		{
+			s: "CREATE TYPE point3 AS (x double precision, y double precision)",
+			stmt: &SQLParser.CreateTypeStatement{Name: "point3", Kind: "COMPOSITE", Attributes: []*SQLParser.Column{
+				{Name: "x", Type: "double"},
+				{Name: "y", Type: "double"},
+			}},
+		},
+		{s: "COMMENT ON TABLE public.users IS 'People'", stmt: &SQLParser.CommentStatement{Object: "TABLE", Name: "public.users", Comment: "People"}},
+		{s: "COMMENT ON COLUMN public.users.email IS NULL", stmt: &SQLParser.CommentStatement{Object: "COLUMN", Name: "public.users", Column: "email"}},
+		{s: "COMMENT ON COLUMN users.email IS 'Login'", stmt: &SQLParser.CommentStatement{Object: "COLUMN", Name: "users", Column: "email", Comment: "Login"}},
+		{s: "COMMENT ON FUNCTION public.f(integer) IS 'Adds'", stmt: &SQLParser.CommentStatement{Object: "FUNCTION", Name: "public.f(integer)", Comment: "Adds"}},
+		{s: "COMMENT ON MATERIALIZED VIEW totals IS ''", stmt: &SQLParser.CommentStatement{Object: "MATERIALIZED VIEW", Name: "totals"}},
+		{
+			s: "ALTER TABLE IF EXISTS ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass), OWNER TO admin",
+			stmt: &SQLParser.AlterTableStatement{
+				Table:    "public.users",
+				IfExists: true,
+				Specs: []*SQLParser.AlterSpec{
+					{
+						Action: "SET DEFAULT", Name: "id",
+						Default: &SQLParser.Call{Name: "NEXTVAL", Args: []SQLParser.Expr{
+							&SQLParser.CastExpr{X: &SQLParser.StringLit{Value: "public.users_id_seq"}, Type: "regclass"},
+						}},
+					},
+					{Action: "OPTION", Name: "owner", Value: "admin"},
+				},
+			},
+		},
+		{
+			s: "ALTER TABLE users ALTER name SET NOT NULL, ALTER note DROP NOT NULL, ALTER COLUMN id DROP IDENTITY IF EXISTS",
+			stmt: &SQLParser.AlterTableStatement{Table: "users", Specs: []*SQLParser.AlterSpec{
+				{Action: "SET NOT NULL", Name: "name"},
+				{Action: "DROP NOT NULL", Name: "note"},
+				{Action: "DROP IDENTITY", Name: "id"},
+			}},
+		},
+		{
+			s: "ALTER TABLE users ALTER COLUMN score SET DATA TYPE numeric(6,2) USING score::numeric, ALTER id ADD GENERATED BY DEFAULT AS IDENTITY (SEQUENCE NAME users_id_seq START WITH 1)",
+			stmt: &SQLParser.AlterTableStatement{Table: "users", Specs: []*SQLParser.AlterSpec{
+				{Action: "SET TYPE", Name: "score", Column: &SQLParser.Column{Name: "score", Type: "decimal", Precision: 6, Scale: 2}},
+				{Action: "ADD IDENTITY", Name: "id", Value: "BY DEFAULT"},
+			}},
+		},
+
+		// Errors
+		{s: "CREATE SEQUENCE s INCREMENT BY x", err: `found "x", expected integer`},
+		{s: "CREATE SEQUENCE s NO START", err: `found "START", expected NO MINVALUE, MAXVALUE or CYCLE`},
+		{s: "CREATE TYPE mood AS ENUM (sad)", err: `found "sad", expected 'label'`},
+		{s: "COMMENT ON COLUMN email IS 'x'", err: `found "email", expected table.column`},
+		{s: "COMMENT ON TABLE users IS 5", err: `found "5", expected 'comment' or NULL`},
+		{s: "CREATE TABLE t (a timestamp with time)", err: `found "time )", expected WITH TIME ZONE`},
+		{s: "CREATE TABLE t (a text[1)", err: `found ")", expected ]`},
+		{s: "CREATE TABLE t (a int GENERATED BY DEFAULT AS (1))", err: `found "(", expected IDENTITY`},
+		{s: "CREATE EXTENSION plpgsql", err: `found CREATE "EXTENSION", expected CREATE TABLE`},
+		{s: "ALTER TABLE users ALTER id ADD GENERATED ALWAYS AS (1)", err: `found "(1)", expected IDENTITY`},
+	}
+
+	for i, tt := range tests {
+		p := SQLParser.NewParser(strings.NewReader(tt.s))
+		p.SetDialect(SQLParser.PostgreSQL)
+		stmt, err := p.ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
+
+func int64p(n int64) *int64 { return &n }
+
+// an abridged pg_dump --schema-only
+const pgDump = `--
+-- PostgreSQL database dump
+--
+
+SET statement_timeout = 0;
+SET client_encoding = 'UTF8';
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+SET default_tablespace = '';
+
+CREATE SCHEMA app;
+ALTER SCHEMA app OWNER TO postgres;
+CREATE EXTENSION IF NOT EXISTS citext WITH SCHEMA public;
+COMMENT ON EXTENSION citext IS 'case-insensitive character strings';
+
+CREATE TYPE app.status AS ENUM (
+    'active',
+    'blocked'
+);
+ALTER TYPE app.status OWNER TO postgres;
+
+CREATE FUNCTION app.touch() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    NEW.updated_at := now();
+    RETURN NEW;
+END;
+$$;
+
+CREATE TABLE app.users (
+    id integer NOT NULL,
+    email character varying(255) NOT NULL,
+    status app.status DEFAULT 'active'::app.status NOT NULL,
+    tags text[] DEFAULT '{}'::text[],
+    matrix integer[][],
+    created_at timestamp(3) with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone,
+    note text COLLATE pg_catalog."C",
+    CONSTRAINT users_email_check CHECK (((email)::text <> ''::text))
+);
+ALTER TABLE app.users OWNER TO postgres;
+COMMENT ON TABLE app.users IS 'People who log in';
+COMMENT ON COLUMN app.users.email IS 'Login, unique';
+
+CREATE SEQUENCE app.users_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+ALTER TABLE app.users_id_seq OWNER TO postgres;
+ALTER SEQUENCE app.users_id_seq OWNED BY app.users.id;
+
+CREATE UNLOGGED TABLE public.events (
+    id bigint NOT NULL,
+    user_id int4,
+    kind text,
+    payload jsonb,
+    at timestamptz
+);
+ALTER TABLE public.events ALTER COLUMN id ADD GENERATED ALWAYS AS IDENTITY (
+    SEQUENCE NAME public.events_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1
+);
+
+CREATE TABLE public.logs (
+    id bigserial PRIMARY KEY,
+    line text
+);
+
+ALTER TABLE ONLY app.users ALTER COLUMN id SET DEFAULT nextval('app.users_id_seq'::regclass);
+ALTER TABLE ONLY app.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY app.users
+    ADD CONSTRAINT users_email_key UNIQUE (email);
+CREATE INDEX events_kind_idx ON public.events USING btree (kind);
+CREATE INDEX events_payload_idx ON ONLY public.events USING gin (payload jsonb_path_ops);
+CREATE INDEX events_lower_kind_idx ON public.events USING btree (lower(kind) DESC NULLS LAST);
+CREATE TRIGGER users_touch BEFORE UPDATE ON app.users FOR EACH ROW EXECUTE FUNCTION app.touch();
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT events_user_id_fkey FOREIGN KEY (user_id) REFERENCES app.users(id) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED;
+
+GRANT USAGE ON SCHEMA app TO PUBLIC;
+REVOKE ALL ON TABLE app.users FROM PUBLIC;
+
+--
+-- PostgreSQL database dump complete
+--
+`
+
+func Test_Postgres_SchemaParser(t *testing.T) {
+	p := SQLParser.NewParser(strings.NewReader(pgDump))
+	p.SetDialect(SQLParser.PostgreSQL)
+	schema, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if names := schema.TableNames(); !reflect.DeepEqual(names, []string{"app.users", "public.events", "public.logs"}) {
+		t.Fatalf("tables mismatch: %v", names)
+	}
+
+	users := schema["app.users"]
+	if users.Comment != "People who log in" || users.Extras["owner"] != "postgres" {
+		t.Errorf("expected the comment and owner of users, found %q and %q", users.Comment, users.Extras["owner"])
+	}
+	if users.PrimaryKey == nil || users.UniqueKeys["users_email_key"] == nil || users.Checks["users_email_check"] == nil {
+		t.Errorf("expected the primary key, unique key and check of users, found %v", users.IndexList)
+	}
+	if users.PrimaryKey.Name != "users_pkey" {
+		t.Errorf("expected the primary key users_pkey, found %q", users.PrimaryKey.Name)
+	}
+	if logs := schema["public.logs"]; logs.PrimaryKey == nil || logs.PrimaryKey.Name != "PRIMARY" {
+		t.Errorf("expected the unnamed primary key of logs, found %#v", logs.PrimaryKey)
+	}
+
+	var tests = []struct {
+		table, column string
+		exp           SQLParser.Column
+	}{
+		{"app.users", "id", SQLParser.Column{Type: "int", AutoIncr: true}},
+		{"app.users", "email", SQLParser.Column{Type: "varchar", Size: 255, Comment: "Login, unique"}},
+		{"app.users", "status", SQLParser.Column{Type: "enum", TypeName: "app.status", Members: []string{"active", "blocked"}}},
+		{"app.users", "tags", SQLParser.Column{Type: "text", Dimensions: 1}},
+		{"app.users", "matrix", SQLParser.Column{Type: "int", Dimensions: 2}},
+		{"app.users", "created_at", SQLParser.Column{Type: "timestamp", Fsp: 3, TimeZone: true}},
+		{"app.users", "updated_at", SQLParser.Column{Type: "timestamp"}},
+		{"app.users", "note", SQLParser.Column{Type: "text", Collation: "pg_catalog.C"}},
+		{"public.events", "id", SQLParser.Column{Type: "bigint", AutoIncr: true, Identity: "ALWAYS"}},
+		{"public.events", "user_id", SQLParser.Column{Type: "int"}},
+		{"public.events", "payload", SQLParser.Column{Type: "jsonb"}},
+		{"public.events", "at", SQLParser.Column{Type: "timestamp", TimeZone: true}},
+		{"public.logs", "id", SQLParser.Column{Type: "bigint", AutoIncr: true}},
+	}
+	for i, tt := range tests {
+		column := schema[tt.table].Columns[tt.column]
+		if column == nil {
+			t.Errorf("%d. missing column %s.%s", i, tt.table, tt.column)
+			continue
+		}
+		got := SQLParser.Column{
+			Type: column.Type, Size: column.Size, Fsp: column.Fsp, Members: column.Members, Nullable: column.Nullable,
+			AutoIncr: column.AutoIncr, Identity: column.Identity, TimeZone: column.TimeZone, Dimensions: column.Dimensions,
+			TypeName: column.TypeName, Collation: column.Collation, Comment: column.Comment,
+		}
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("%d. %s.%s mismatch:\n  exp=%#v\n  got=%#v", i, tt.table, tt.column, tt.exp, got)
+		}
+	}
+
+	if s := users.Columns["tags"].Default.(SQLParser.Expr).String(); s != "'{}'::text[]" {
+		t.Errorf("expected the default '{}'::text[], found %s", s)
+	}
+
+	events := schema["public.events"]
+	if key := events.Keys["events_payload_idx"]; key == nil || key.Using != "GIN" {
+		t.Errorf("expected a GIN index on payload, found %#v", key)
+	}
+	if key := events.Keys["events_lower_kind_idx"]; key == nil || key.Columns[0].Expr.String() != "LOWER(kind)" || !key.Columns[0].Desc {
+		t.Errorf("expected an index on lower(kind), found %#v", key)
+	}
+	if fk := events.Constraints["events_user_id_fkey"]; fk == nil || fk.RefSchema != "app" || !fk.Deferrable || !fk.InitiallyDeferred {
+		t.Errorf("expected a deferred foreign key to app.users, found %#v", fk)
+	}
+}
@@ -0,0 +1,117 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Privilege_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{
+			s: "GRANT SELECT, INSERT (id, name), ALL PRIVILEGES ON shop.* TO 'app'@'10.0.%', `ro`@localhost WITH GRANT OPTION",
+			stmt: &SQLParser.GrantStatement{
+				Privileges: []*SQLParser.Privilege{
+					{Name: "SELECT"},
+					{Name: "INSERT", Columns: []string{"id", "name"}},
+					{Name: "ALL"},
+				},
+				On:              &SQLParser.GrantObject{Database: "shop", Name: "*"},
+				To:              []*SQLParser.Grantee{{User: "app", Host: "10.0.%"}, {User: "ro", Host: "localhost"}},
+				WithGrantOption: true,
+			},
+		},
+		{
+			s: `GRANT create view, show view ON TABLE orders TO reporter;`,
+			stmt: &SQLParser.GrantStatement{
+				Privileges: []*SQLParser.Privilege{{Name: "CREATE VIEW"}, {Name: "SHOW VIEW"}},
+				On:         &SQLParser.GrantObject{Type: "TABLE", Name: "orders"},
+				To:         []*SQLParser.Grantee{{User: "reporter"}},
+			},
+		},
+		{
+			s: `GRANT EXECUTE ON PROCEDURE *.* TO CURRENT_USER()`,
+			stmt: &SQLParser.GrantStatement{
+				Privileges: []*SQLParser.Privilege{{Name: "EXECUTE"}},
+				On:         &SQLParser.GrantObject{Type: "PROCEDURE", Database: "*", Name: "*"},
+				To:         []*SQLParser.Grantee{{User: "CURRENT_USER"}},
+			},
+		},
+		{
+			s: `GRANT app_read, app_write TO 'bob'@'%' WITH ADMIN OPTION`,
+			stmt: &SQLParser.GrantStatement{
+				Roles:           []string{"app_read", "app_write"},
+				To:              []*SQLParser.Grantee{{User: "bob", Host: "%"}},
+				WithAdminOption: true,
+			},
+		},
+		{
+			s: `REVOKE UPDATE, DELETE ON shop.orders FROM 'app'@'%'`,
+			stmt: &SQLParser.RevokeStatement{
+				Privileges: []*SQLParser.Privilege{{Name: "UPDATE"}, {Name: "DELETE"}},
+				On:         &SQLParser.GrantObject{Database: "shop", Name: "orders"},
+				From:       []*SQLParser.Grantee{{User: "app", Host: "%"}},
+			},
+		},
+		{
+			s: `REVOKE ALL PRIVILEGES, GRANT OPTION FROM bob`,
+			stmt: &SQLParser.RevokeStatement{
+				Privileges: []*SQLParser.Privilege{{Name: "ALL"}, {Name: "GRANT OPTION"}},
+				From:       []*SQLParser.Grantee{{User: "bob"}},
+			},
+		},
+		{
+			s: `REVOKE app_read FROM bob`,
+			stmt: &SQLParser.RevokeStatement{
+				Roles: []string{"app_read"},
+				From:  []*SQLParser.Grantee{{User: "bob"}},
+			},
+		},
+		{
+			s: `CREATE USER IF NOT EXISTS 'app'@'%' IDENTIFIED BY 's3cret', 'legacy' IDENTIFIED WITH mysql_native_password AS '*ABC' DEFAULT ROLE app_read PASSWORD EXPIRE NEVER`,
+			stmt: &SQLParser.CreateUserStatement{
+				IfNotExists: true,
+				Users: []*SQLParser.UserSpec{
+					{Grantee: SQLParser.Grantee{User: "app", Host: "%"}, Password: "s3cret"},
+					{Grantee: SQLParser.Grantee{User: "legacy"}, Plugin: "mysql_native_password", AuthString: "*ABC"},
+				},
+				DefaultRoles: []string{"app_read"},
+				Options:      "PASSWORD EXPIRE NEVER",
+			},
+		},
+		{
+			s:    `DROP USER IF EXISTS 'app'@'%', bob`,
+			stmt: &SQLParser.DropUserStatement{IfExists: true, Users: []*SQLParser.Grantee{{User: "app", Host: "%"}, {User: "bob"}}},
+		},
+		{
+			s:    `CREATE ROLE app_read, 'app_write'`,
+			stmt: &SQLParser.CreateRoleStatement{Roles: []*SQLParser.Grantee{{User: "app_read"}, {User: "app_write"}}},
+		},
+		{
+			s:    `DROP ROLE IF EXISTS app_read`,
+			stmt: &SQLParser.DropRoleStatement{IfExists: true, Roles: []*SQLParser.Grantee{{User: "app_read"}}},
+		},
+
+		// Errors
+		{s: `GRANT ON t TO bob`, err: `found "ON", expected privilege`},
+		{s: `GRANT SELECT ON t bob`, err: `found "bob", expected TO`},
+		{s: `GRANT SELECT ON t TO bob WITH OPTION`, err: `found "OPTION EOF", expected GRANT OPTION or ADMIN OPTION`},
+		{s: `REVOKE SELECT ON t TO bob`, err: `found "TO", expected FROM`},
+		{s: `CREATE USER bob IDENTIFIED BY secret`, err: `found "secret", expected 'password'`},
+		{s: `CREATE EVENT`, err: `found CREATE "EVENT", expected CREATE TABLE`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
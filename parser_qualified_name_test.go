@@ -0,0 +1,206 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_QualifiedName_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{
+			s: "SELECT shop.orders.id, o.total, o.* FROM shop.orders WHERE shop.orders.id = 1",
+			stmt: &SQLParser.SelectStatement{
+				Fields:    []string{"shop.orders.id", "o.total", "o.*"},
+				Exprs: []SQLParser.Expr{
+					&SQLParser.Ident{Schema: "shop", Table: "orders", Name: "id"},
+					&SQLParser.Ident{Table: "o", Name: "total"},
+					&SQLParser.Ident{Table: "o", Name: "*"},
+				},
+				TableName: "shop.orders",
+				Where: &SQLParser.BinaryExpr{
+					Op:  SQLParser.EQUAL,
+					LHS: &SQLParser.Ident{Schema: "shop", Table: "orders", Name: "id"},
+					RHS: &SQLParser.NumberLit{Value: "1"},
+				},
+			},
+		},
+		{
+			s: "UPDATE `shop`.`orders` SET total = 0 WHERE orders.id = 1",
+			stmt: &SQLParser.UpdateStatement{
+				TableName: "shop.orders",
+				Fields:    []string{"total", "0", "id"},
+				Set:       []*SQLParser.Assignment{{Column: "total", Value: &SQLParser.NumberLit{Value: "0"}}},
+				Where: &SQLParser.BinaryExpr{
+					Op:  SQLParser.EQUAL,
+					LHS: &SQLParser.Ident{Table: "orders", Name: "id"},
+					RHS: &SQLParser.NumberLit{Value: "1"},
+				},
+			},
+		},
+		{s: "DROP TABLE shop.orders, `my.db`.t", stmt: &SQLParser.DropTableStatement{Tables: []string{"shop.orders", "`my.db`.t"}}},
+		{s: "RENAME TABLE shop.orders TO archive.orders", stmt: &SQLParser.RenameTableStatement{Tables: []*SQLParser.TableRename{{From: "shop.orders", To: "archive.orders"}}}},
+		{
+			s: "CREATE DATABASE IF NOT EXISTS `shop` DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci DEFAULT ENCRYPTION='N'",
+			stmt: &SQLParser.CreateDatabaseStatement{
+				Name:        "shop",
+				IfNotExists: true,
+				Charset:     "utf8mb4",
+				Collation:   "utf8mb4_0900_ai_ci",
+				Options:     map[string]string{"charset": "utf8mb4", "collate": "utf8mb4_0900_ai_ci", "encryption": "N"},
+			},
+		},
+		{s: "SHOW CREATE TABLE shop.`user`", stmt: &SQLParser.ShowStatement{Kind: "CREATE TABLE", Name: "shop.user"}},
+		{s: "SHOW COLUMNS FROM shop.user", stmt: &SQLParser.ShowStatement{Kind: "COLUMNS", Name: "shop.user"}},
+		{s: "DESCRIBE shop.user", stmt: &SQLParser.DescribeStatement{Table: "shop.user"}},
+		{s: "DESC c.shop.user name;", stmt: &SQLParser.DescribeStatement{Table: "c.shop.user", Column: "name"}},
+		{s: "CREATE SCHEMA shop", stmt: &SQLParser.CreateDatabaseStatement{Name: "shop", Options: map[string]string{}}},
+		{s: "DROP SCHEMA IF EXISTS shop", stmt: &SQLParser.DropDatabaseStatement{Name: "shop", IfExists: true}},
+
+		// Errors
+		{s: `SELECT a. FROM t`, err: `found "FROM", expected column name`},
+		{s: `SELECT a FROM shop.`, err: `found "EOF", expected table name`},
+		{s: `SELECT a FROM t WHERE t.=1`, err: `found "=", expected column name`},
+		{s: `DESCRIBE shop.`, err: `found "EOF", expected table name`},
+		{s: `SHOW INDEX FROM shop.`, err: `found "EOF", expected name`},
+		{s: `CREATE TABLE shop.(a int)`, err: `found "(", expected table name`},
+		{s: `CREATE TABLE a.b.c.d (a int)`, err: `found ".", expected table option`},
+		{s: `CREATE DATABASE`, err: `found "EOF", expected database name`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
+
+func Test_QualifiedName_SchemaParser(t *testing.T) {
+	s := "CREATE DATABASE IF NOT EXISTS `billing`;\n" +
+		"USE `billing`;\n" +
+		"CREATE TABLE `accounts` (`id` int PRIMARY KEY, CHECK (`id` > 0));\n" +
+		"CREATE DATABASE IF NOT EXISTS `shop`;\n" +
+		"USE `shop`;\n" +
+		"CREATE TABLE `accounts` (`id` int PRIMARY KEY);\n" +
+		"CREATE TABLE `orders` (\n" +
+		"  `id` int PRIMARY KEY,\n" +
+		"  `account_id` int REFERENCES billing.accounts(id),\n" +
+		"  `shop_account_id` int REFERENCES accounts(id)\n" +
+		");\n" +
+		"CREATE TABLE archive.orders LIKE orders;\n" +
+		"CREATE TABLE `local` (`id` int);\n" +
+		"DROP DATABASE IF EXISTS `archive`;\n" +
+		"CREATE TABLE c.archive.old (`id` int);"
+
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if names := schema.TableNames(); !reflect.DeepEqual(names, []string{"accounts", "billing.accounts", "c.archive.old", "local", "orders"}) {
+		t.Errorf("tables mismatch: %v", names)
+	}
+	if databases := schema.Databases(); !reflect.DeepEqual(databases, []string{"archive", "billing", "shop"}) {
+		t.Errorf("databases mismatch: %v", databases)
+	}
+	if shop := schema.Database("shop"); len(shop) != 3 || shop["orders"] != schema["orders"] {
+		t.Errorf("expected the tables of shop by their unqualified names, found %v", shop.TableNames())
+	}
+	if orders := schema.Table(SQLParser.QualifiedName{Schema: "shop", Name: "orders"}); orders == nil || orders != schema["orders"] || orders.Name != "orders" {
+		t.Errorf("expected shop.orders by its qualified name, found %#v", orders)
+	}
+
+	if name := schema["c.archive.old"].QualifiedName(); name != (SQLParser.QualifiedName{Catalog: "c", Schema: "archive", Name: "old"}) {
+		t.Errorf("qualified name mismatch: %#v", name)
+	}
+	if check := schema["billing.accounts"].CheckList[0].Name; check != "accounts_chk_1" {
+		t.Errorf("expected check accounts_chk_1, found %q", check)
+	}
+
+	orders := schema["orders"]
+	for i, ref := range []string{"billing.accounts", "shop.accounts"} {
+		constraint := orders.ConstraintList[i]
+		if got := (SQLParser.QualifiedName{Catalog: constraint.RefCatalog, Schema: constraint.RefSchema, Name: constraint.TableName}).String(); got != ref {
+			t.Errorf("%d. expected a reference to %s, found %s", i, ref, got)
+		}
+	}
+	if constraint := orders.ConstraintList[0].Index; constraint != "orders_ibfk_1" {
+		t.Errorf("expected constraint orders_ibfk_1, found %q", constraint)
+	}
+}
+
+func Test_QualifiedName_Use(t *testing.T) {
+	s := "USE shop;\n" +
+		"CREATE TABLE user (id int);\n" +
+		"USE billing;\n" +
+		"CREATE TABLE user (id int, iban varchar(34));\n" +
+		"ALTER TABLE shop.user ADD name varchar(20);\n" +
+		"RENAME TABLE user TO member;\n" +
+		"USE shop;"
+
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := schema.TableNames(); !reflect.DeepEqual(names, []string{"billing.member", "user"}) {
+		t.Fatalf("tables mismatch: %v", names)
+	}
+	if user := schema["user"]; user.Name != "user" || user.Database != "shop" || len(user.ColumnList) != 2 {
+		t.Errorf("expected shop.user with a name column, found %#v", user)
+	}
+	if member := schema["billing.member"]; member.Name != "member" || member.Database != "billing" || len(member.ColumnList) != 2 {
+		t.Errorf("expected billing.user renamed to member, found %#v", member)
+	}
+
+	// statements applied one by one in the Parser's database key the tables
+	// like Parse
+	p := SQLParser.NewParser(strings.NewReader(s))
+	applied := make(SQLParser.Schema)
+	for {
+		stmt, err := p.ParseStatement()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stmt == nil {
+			break
+		}
+		if err := applied.ApplyIn(stmt, p.Database()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p.Database() != "shop" || !reflect.DeepEqual(applied.TableNames(), schema.TableNames()) {
+		t.Errorf("expected the tables of Parse in shop, found %v in %q", applied.TableNames(), p.Database())
+	}
+	if user := applied["user"]; user == nil || user.Database != "shop" || len(user.ColumnList) != 2 {
+		t.Errorf("expected shop.user with a name column, found %#v", user)
+	}
+}
+
+func Test_QualifiedName_String(t *testing.T) {
+	var tests = []struct {
+		name SQLParser.QualifiedName
+		s    string
+	}{
+		{name: SQLParser.QualifiedName{Name: "t"}, s: "t"},
+		{name: SQLParser.QualifiedName{Schema: "shop", Name: "t"}, s: "shop.t"},
+		{name: SQLParser.QualifiedName{Catalog: "c", Schema: "shop", Name: "t"}, s: "c.shop.t"},
+		{name: SQLParser.QualifiedName{Schema: "my.db", Name: "a`b"}, s: "`my.db`.`a``b`"},
+	}
+
+	for i, tt := range tests {
+		if s := tt.name.String(); s != tt.s {
+			t.Errorf("%d. expected %q, found %q", i, tt.s, s)
+		}
+		if name := SQLParser.ParseQualifiedName(tt.s); name != tt.name {
+			t.Errorf("%d. %q: expected %#v, found %#v", i, tt.s, tt.name, name)
+		}
+	}
+}
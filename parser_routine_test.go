@@ -0,0 +1,157 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Routine_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{
+			s: "CREATE DEFINER=`root`@`%` TRIGGER `trg` BEFORE INSERT ON `user` FOR EACH ROW FOLLOWS other SET NEW.created = NOW();",
+			stmt: &SQLParser.CreateTriggerStatement{Trigger: &SQLParser.Trigger{
+				Name: "trg", Definer: &SQLParser.Grantee{User: "root", Host: "%"},
+				Timing: "BEFORE", Event: "INSERT", Table: "user", Order: "FOLLOWS", Other: "other",
+				Body: &SQLParser.Body{Text: "SET NEW.created = NOW()", Start: 91, End: 114},
+			}},
+		},
+		{
+			s: "CREATE PROCEDURE p(IN id INT UNSIGNED, OUT total DECIMAL(10,2))\nREADS SQL DATA\nBEGIN\n  SELECT 1;\nEND",
+			stmt: &SQLParser.CreateRoutineStatement{Routine: &SQLParser.Routine{
+				Kind: "PROCEDURE", Name: "p",
+				Params: []*SQLParser.RoutineParam{
+					{Mode: "IN", Column: &SQLParser.Column{Name: "id", Type: "int", Unsigned: true}},
+					{Mode: "OUT", Column: &SQLParser.Column{Name: "total", Type: "decimal", Precision: 10, Scale: 2}},
+				},
+				DataAccess: "READS SQL DATA",
+				Body:       &SQLParser.Body{Text: "BEGIN\n  SELECT 1;\nEND", Start: 79, End: 100},
+			}},
+		},
+		{
+			s: "CREATE FUNCTION IF NOT EXISTS f(s varchar(20) CHARSET utf8mb4) RETURNS int DETERMINISTIC NO SQL COMMENT 'len' SQL SECURITY INVOKER RETURN CHAR_LENGTH(s)",
+			stmt: &SQLParser.CreateRoutineStatement{IfNotExists: true, Routine: &SQLParser.Routine{
+				Kind: "FUNCTION", Name: "f",
+				Params:        []*SQLParser.RoutineParam{{Column: &SQLParser.Column{Name: "s", Type: "varchar", Size: 20, Charset: "utf8mb4"}}},
+				Returns:       &SQLParser.Column{Type: "int"},
+				Deterministic: true, DataAccess: "NO SQL", Comment: "len", Security: "INVOKER",
+				Body: &SQLParser.Body{Text: "RETURN CHAR_LENGTH(s)", Start: 131, End: 152},
+			}},
+		},
+		{s: `DROP TRIGGER IF EXISTS trg`, stmt: &SQLParser.DropTriggerStatement{Name: "trg", IfExists: true}},
+		{s: `DROP PROCEDURE p`, stmt: &SQLParser.DropRoutineStatement{Kind: "PROCEDURE", Name: "p"}},
+		{s: `DROP FUNCTION IF EXISTS f`, stmt: &SQLParser.DropRoutineStatement{Kind: "FUNCTION", Name: "f", IfExists: true}},
+		{s: "DELIMITER $$\n", stmt: &SQLParser.DelimiterStatement{Delimiter: "$$"}},
+
+		// Errors
+		{s: `CREATE TRIGGER t DURING INSERT ON a FOR EACH ROW SET x = 1`, err: `found "DURING", expected BEFORE or AFTER`},
+		{s: `CREATE TRIGGER t AFTER SELECT ON a FOR EACH ROW SET x = 1`, err: `found "SELECT", expected INSERT, UPDATE or DELETE`},
+		{s: `CREATE TRIGGER t AFTER DELETE ON a FOR ROW SET x = 1`, err: `found "FOR ROW SET", expected FOR EACH ROW`},
+		{s: `CREATE TRIGGER t AFTER DELETE ON a FOR EACH ROW`, err: `found "EOF", expected routine body`},
+		{s: `CREATE FUNCTION f() int RETURN 1`, err: `found "int", expected RETURNS`},
+		{s: `CREATE PROCEDURE p(IN) SELECT 1`, err: `found ")", expected parameter name`},
+		{s: `CREATE PROCEDURE p() NOT SQL SELECT 1`, err: `found "SQL", expected NOT DETERMINISTIC`},
+		{s: `DELIMITER`, err: `found "EOF", expected delimiter`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
+
+func Test_Routine_Bodies(t *testing.T) {
+	var tests = []struct {
+		s    string
+		body string
+	}{
+		// without DELIMITER, semicolons inside blocks do not end the statement
+		{
+			s:    "CREATE PROCEDURE p() BEGIN DECLARE x INT; lbl: LOOP IF x > 0 THEN LEAVE lbl; END IF; END LOOP lbl; SET x = CASE WHEN x THEN 1 ELSE 2 END; CASE x WHEN 1 THEN SELECT 1; END CASE; BEGIN END; END; SELECT * FROM t",
+			body: "BEGIN DECLARE x INT; lbl: LOOP IF x > 0 THEN LEAVE lbl; END IF; END LOOP lbl; SET x = CASE WHEN x THEN 1 ELSE 2 END; CASE x WHEN 1 THEN SELECT 1; END CASE; BEGIN END; END",
+		},
+		// with DELIMITER, only the delimiter ends the statement
+		{
+			s:    "DELIMITER //\nCREATE PROCEDURE p() SELECT 1; SELECT 2 //\nDELIMITER ;\nSELECT * FROM t",
+			body: "SELECT 1; SELECT 2",
+		},
+	}
+
+	for i, tt := range tests {
+		stmts, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatements()
+		if err != nil {
+			t.Errorf("%d. %q: %s", i, tt.s, err)
+			continue
+		}
+
+		var body *SQLParser.Body
+		for _, stmt := range stmts {
+			if create, ok := stmt.(*SQLParser.CreateRoutineStatement); ok {
+				body = create.Routine.Body
+			}
+		}
+		if body == nil || body.Text != tt.body || tt.s[body.Start:body.End] != tt.body {
+			t.Errorf("%d. %q: body mismatch:\n  exp=%q\n  got=%#v", i, tt.s, tt.body, body)
+		}
+		if _, ok := stmts[len(stmts)-1].(*SQLParser.SelectStatement); !ok {
+			t.Errorf("%d. %q: expected the last statement to be SELECT, found %#v", i, tt.s, stmts[len(stmts)-1])
+		}
+	}
+}
+
+func Test_Routine_SchemaParser(t *testing.T) {
+	s := "CREATE TABLE `user` (\n  `id` int NOT NULL,\n  `updated` datetime\n);\n" +
+		"/*!50003 SET @saved_cs_client = @@character_set_client */ ;\n" +
+		"DELIMITER ;;\n" +
+		"CREATE DEFINER=`root`@`localhost` TRIGGER `b` BEFORE UPDATE ON `user` FOR EACH ROW BEGIN\n  SET NEW.updated = NOW();\n  SET @n = @n + 1;\nEND ;;\n" +
+		"CREATE TRIGGER `a` BEFORE UPDATE ON `user` FOR EACH ROW PRECEDES `b` SET @n = 0 ;;\n" +
+		"CREATE PROCEDURE `cleanup`()\nBEGIN\n  DELETE FROM `user` WHERE id < 0;\nEND ;;\n" +
+		"DELIMITER ;\n" +
+		"RENAME TABLE `user` TO `member`;\n" +
+		"CREATE TABLE `log` (\n  `id` int\n);"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp, got := []string{"log", "member"}, schema.TableNames(); !reflect.DeepEqual(exp, got) {
+		t.Fatalf("tables mismatch:\n  exp=%q\n  got=%q", exp, got)
+	}
+	triggers := schema["member"].Triggers
+	if len(triggers) != 2 || triggers[0].Name != "a" || triggers[1].Name != "b" || triggers[1].Table != "member" {
+		t.Fatalf("expected triggers a, b on member, found %#v", triggers)
+	}
+	if exp := "BEGIN\n  SET NEW.updated = NOW();\n  SET @n = @n + 1;\nEND"; triggers[1].Body.Text != exp {
+		t.Errorf("body mismatch:\n  exp=%q\n  got=%q", exp, triggers[1].Body.Text)
+	}
+}
+
+func Test_Routine_SchemaErrors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: `CREATE TRIGGER t2 AFTER INSERT ON missing FOR EACH ROW SET @n = 1`, err: `cannot create trigger "t2", table "missing" does not exist`},
+		{s: `CREATE TRIGGER t AFTER INSERT ON a FOR EACH ROW SET @n = 1`, err: `cannot create trigger "t", it already exists`},
+		{s: `CREATE TRIGGER t2 AFTER INSERT ON a FOR EACH ROW FOLLOWS x SET @n = 1`, err: `cannot create trigger "t2", trigger "x" does not exist`},
+		{s: `DROP TRIGGER x`, err: `cannot drop trigger "x", it does not exist`},
+		{s: `DROP TRIGGER t`},
+	}
+
+	for i, tt := range tests {
+		s := "CREATE TABLE a (id int);\nCREATE TRIGGER t AFTER INSERT ON a FOR EACH ROW SET @n = 0;\n" + tt.s
+		_, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
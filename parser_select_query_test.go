@@ -18,6 +18,7 @@ func Test_SELECT_QueryParser(t *testing.T) {
 			s: `SELECT name FROM tbl`,
 			stmt: &SQLParser.SelectStatement{
 				Fields:    []string{"name"},
+				Exprs:     []SQLParser.Expr{&SQLParser.Ident{Name: "name"}},
 				TableName: "tbl",
 			},
 		},
@@ -27,6 +28,9 @@ func Test_SELECT_QueryParser(t *testing.T) {
 			s: `SELECT first_name, last_name, age FROM my_table`,
 			stmt: &SQLParser.SelectStatement{
 				Fields:    []string{"first_name", "last_name", "age"},
+				Exprs: []SQLParser.Expr{
+					&SQLParser.Ident{Name: "first_name"}, &SQLParser.Ident{Name: "last_name"}, &SQLParser.Ident{Name: "age"},
+				},
 				TableName: "my_table",
 			},
 		},
@@ -36,15 +40,43 @@ func Test_SELECT_QueryParser(t *testing.T) {
 			s: `SELECT * FROM my_table`,
 			stmt: &SQLParser.SelectStatement{
 				Fields:    []string{"*"},
+				Exprs:     []SQLParser.Expr{nil},
 				TableName: "my_table",
 			},
 		},
 
+		// Aliased expressions and qualified stars
+		{
+			s: "SELECT `t`.`a` AS `a`, concat(b, '!') AS c, (a + 1), t.* FROM t",
+			stmt: &SQLParser.SelectStatement{
+				Fields:    []string{"t.a", "CONCAT(b, '!')", "(a + 1)", "t.*"},
+				Exprs: []SQLParser.Expr{
+					&SQLParser.Ident{Table: "t", Name: "a"},
+					&SQLParser.Call{Name: "CONCAT", Args: []SQLParser.Expr{&SQLParser.Ident{Name: "b"}, &SQLParser.StringLit{Value: "!"}}},
+					&SQLParser.ParenExpr{X: &SQLParser.BinaryExpr{Op: SQLParser.PLUS, LHS: &SQLParser.Ident{Name: "a"}, RHS: &SQLParser.NumberLit{Value: "1"}}},
+					&SQLParser.Ident{Table: "t", Name: "*"},
+				},
+				Aliases:   []string{"a", "c", "", ""},
+				TableName: "t",
+			},
+		},
+
+		// Values only
+		{
+			s: "SELECT \n 1 AS `a`,\n 1 AS `b`",
+			stmt: &SQLParser.SelectStatement{
+				Fields:  []string{"1", "1"},
+				Exprs:   []SQLParser.Expr{&SQLParser.NumberLit{Value: "1"}, &SQLParser.NumberLit{Value: "1"}},
+				Aliases: []string{"a", "b"},
+			},
+		},
+
 		// Errors
 		{s: `foo`, err: `found "foo", expected SELECT`},
 		{s: `SELECT !`, err: `found "!", expected field`},
 		{s: `SELECT field xxx`, err: `found "xxx", expected FROM`},
 		{s: `SELECT field FROM *`, err: `found "*", expected table name`},
+		{s: `SELECT field AS FROM t`, err: `found "FROM", expected alias`},
 	}
 
 	for i, tt := range tests {
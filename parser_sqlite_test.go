@@ -0,0 +1,152 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+// the output of sqlite3 .schema for a small app database
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS "users" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "email" VARCHAR(255) NOT NULL ON CONFLICT FAIL UNIQUE ON CONFLICT IGNORE,
+  [display name] TEXT COLLATE NOCASE,
+  "balance" DECIMAL(10, 2) DEFAULT 0,
+  "score" REAL,
+  "big" UNSIGNED BIG INT,
+  "avatar" BLOB,
+  "created_at" DATETIME DEFAULT CURRENT_TIMESTAMP,
+  "misc"
+);
+CREATE TABLE sqlite_sequence(name,seq);
+CREATE TABLE IF NOT EXISTS tags (
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED,
+  tag TEXT NOT NULL,
+  PRIMARY KEY (user_id, tag) ON CONFLICT REPLACE
+) WITHOUT ROWID;
+CREATE TABLE "posts" (
+  "id" INTEGER NOT NULL,
+  "user_id" INTEGER REFERENCES users,
+  "parent_id" INTEGER REFERENCES "posts" ON DELETE CASCADE,
+  "tag" TEXT REFERENCES tags,
+  PRIMARY KEY("id" AUTOINCREMENT)
+);
+CREATE TABLE settings ("key" TEXT PRIMARY KEY DESC, value ANY) STRICT, WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS idx_users_name ON users ([display name] COLLATE NOCASE DESC);
+CREATE UNIQUE INDEX idx_users_email ON users (email) WHERE email <> '';
+CREATE VIRTUAL TABLE search USING fts5(body, tokenize='porter');
+CREATE TRIGGER IF NOT EXISTS users_touch AFTER UPDATE OF email, "balance" ON users
+WHEN new.balance < 0
+BEGIN
+  UPDATE users SET balance = 0 WHERE id = new.id;
+  DELETE FROM tags WHERE user_id = new.id;
+END;
+CREATE VIEW active AS SELECT id, email FROM users;
+CREATE TRIGGER active_insert INSTEAD OF INSERT ON active BEGIN INSERT INTO users (email) VALUES (new.email); END;
+PRAGMA foreign_keys=ON;
+`
+
+func Test_SQLite_SchemaParser(t *testing.T) {
+	p := SQLParser.NewParser(strings.NewReader(sqliteSchema))
+	p.SetDialect(SQLParser.SQLite)
+	schema, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if names := schema.TableNames(); !reflect.DeepEqual(names, []string{"active", "posts", "settings", "sqlite_sequence", "tags", "users"}) {
+		t.Fatalf("tables mismatch: %v", names)
+	}
+
+	var tests = []struct {
+		table, column string
+		exp           SQLParser.Column
+	}{
+		{"users", "id", SQLParser.Column{Type: "int", Affinity: "INTEGER", AutoIncr: true}},
+		{"users", "email", SQLParser.Column{Type: "varchar", Size: 255, Affinity: "TEXT", OnConflict: "FAIL"}},
+		{"users", "display name", SQLParser.Column{Type: "text", Affinity: "TEXT", Collation: "NOCASE"}},
+		{"users", "balance", SQLParser.Column{Type: "decimal", Precision: 10, Scale: 2, Affinity: "NUMERIC"}},
+		{"users", "score", SQLParser.Column{Type: "double", Affinity: "REAL"}},
+		{"users", "big", SQLParser.Column{Type: "unsigned big int", Affinity: "INTEGER"}},
+		{"users", "avatar", SQLParser.Column{Type: "blob", Affinity: "BLOB"}},
+		{"users", "created_at", SQLParser.Column{Type: "datetime", Affinity: "NUMERIC"}},
+		{"users", "misc", SQLParser.Column{Affinity: "BLOB"}},
+		{"posts", "id", SQLParser.Column{Type: "int", Affinity: "INTEGER", AutoIncr: true}},
+		{"sqlite_sequence", "seq", SQLParser.Column{Affinity: "BLOB"}},
+		{"settings", "value", SQLParser.Column{Type: "any", Affinity: "NUMERIC"}},
+	}
+	for i, tt := range tests {
+		column := schema[tt.table].Columns[tt.column]
+		if column == nil {
+			t.Errorf("%d. missing column %s.%s", i, tt.table, tt.column)
+			continue
+		}
+		got := SQLParser.Column{
+			Type: column.Type, Size: column.Size, Precision: column.Precision, Scale: column.Scale, Affinity: column.Affinity,
+			AutoIncr: column.AutoIncr, OnConflict: column.OnConflict, Collation: column.Collation,
+		}
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("%d. %s.%s mismatch:\n  exp=%#v\n  got=%#v", i, tt.table, tt.column, tt.exp, got)
+		}
+	}
+
+	users := schema["users"]
+	if key := users.UniqueKeys["email"]; key == nil || key.OnConflict != "IGNORE" {
+		t.Errorf("expected a unique key on email ignoring conflicts, found %#v", key)
+	}
+	if users.Keys["idx_users_name"] == nil || users.UniqueKeys["idx_users_email"] == nil {
+		t.Errorf("expected the indexes of users, found %v", users.IndexList)
+	}
+
+	tags := schema["tags"]
+	if !tags.WithoutRowID || tags.Strict || tags.PrimaryKey.OnConflict != "REPLACE" {
+		t.Errorf("expected a WITHOUT ROWID table replacing on conflict, found %v %v %#v", tags.WithoutRowID, tags.Strict, tags.PrimaryKey)
+	}
+	settings := schema["settings"]
+	if !settings.WithoutRowID || !settings.Strict || !settings.PrimaryKey.Columns[0].Desc {
+		t.Errorf("expected a STRICT WITHOUT ROWID table, found %v %v", settings.WithoutRowID, settings.Strict)
+	}
+
+	posts := schema["posts"]
+	for i, exp := range [][]string{{"id"}, {"id"}, {"user_id", "tag"}} {
+		if c := posts.ConstraintList[i]; !reflect.DeepEqual(c.RefColumns, exp) {
+			t.Errorf("expected the reference of %v to %s%v, found %v", c.Columns, c.TableName, exp, c.RefColumns)
+		}
+	}
+
+	if len(users.Triggers) != 1 {
+		t.Fatalf("expected a trigger on users, found %d", len(users.Triggers))
+	}
+	trigger := users.Triggers[0]
+	if trigger.Timing != "AFTER" || !reflect.DeepEqual(trigger.Columns, []string{"email", "balance"}) || trigger.When.String() != "new.balance < 0" {
+		t.Errorf("trigger mismatch: %#v", trigger)
+	}
+	if !strings.HasPrefix(trigger.Body.Text, "BEGIN") || !strings.HasSuffix(trigger.Body.Text, "END") {
+		t.Errorf("expected the BEGIN ... END body, found %q", trigger.Body.Text)
+	}
+	if triggers := schema["active"].Triggers; len(triggers) != 1 || triggers[0].Timing != "INSTEAD OF" {
+		t.Errorf("expected an INSTEAD OF trigger on active, found %v", triggers)
+	}
+}
+
+func Test_SQLite_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TABLE t (a INTEGER PRIMARY KEY ON CONFLICT SKIP)", err: `found "SKIP", expected ROLLBACK, ABORT, FAIL, IGNORE or REPLACE`},
+		{s: "CREATE TABLE t (a INTEGER NOT NULL ON UPDATE)", err: `found "UPDATE", expected ON CONFLICT`},
+		{s: "CREATE TABLE t (a VARCHAR(x))", err: `found "x", expected type(integer)`},
+		{s: "CREATE TABLE t (a INTEGER) WITHOUT KEYS", err: `found "KEYS", expected WITHOUT ROWID`},
+		{s: "CREATE TABLE t (a INTEGER); CREATE TRIGGER g INSTEAD INSERT ON t BEGIN SELECT 1; END", err: `found "INSERT", expected INSTEAD OF`},
+	}
+
+	for i, tt := range tests {
+		p := SQLParser.NewParser(strings.NewReader(tt.s))
+		p.SetDialect(SQLParser.SQLite)
+		if _, err := p.Parse(); errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
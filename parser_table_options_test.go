@@ -0,0 +1,77 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_TableOptions_Parser(t *testing.T) {
+	s := "CREATE TABLE `user` (\n  `id` int NOT NULL\n) ENGINE=InnoDB AUTO_INCREMENT=100 DEFAULT CHARSET=utf8mb4 " +
+		"COLLATE=utf8mb4_unicode_ci ROW_FORMAT=dynamic KEY_BLOCK_SIZE 8, COMMENT='user''s \\'table\\'\\n' " +
+		"STATS_PERSISTENT=0 DATA DIRECTORY='/var/lib' UNION=(a,b);"
+
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := schema["user"]
+
+	if user.Engine != "InnoDB" || user.Charset != "utf8mb4" || user.Collation != "utf8mb4_unicode_ci" {
+		t.Errorf("unexpected engine %q, charset %q or collation %q", user.Engine, user.Charset, user.Collation)
+	}
+	if user.AutoIncrement != 100 || user.KeyBlockSize != 8 || user.RowFormat != "DYNAMIC" {
+		t.Errorf("unexpected auto_increment %d, key_block_size %d or row_format %q", user.AutoIncrement, user.KeyBlockSize, user.RowFormat)
+	}
+	if exp := "user's 'table'\n"; user.Comment != exp {
+		t.Errorf("comment mismatch: exp=%q got=%q", exp, user.Comment)
+	}
+
+	extras := map[string]string{
+		"engine":           "InnoDB",
+		"auto_increment":   "100",
+		"charset":          "utf8mb4",
+		"collate":          "utf8mb4_unicode_ci",
+		"row_format":       "dynamic",
+		"key_block_size":   "8",
+		"comment":          "user's 'table'\n",
+		"stats_persistent": "0",
+		"data directory":   "/var/lib",
+		"union":            "(a, b)",
+	}
+	if !reflect.DeepEqual(extras, user.Extras) {
+		t.Errorf("extras mismatch:\n  exp=%v\n  got=%v", extras, user.Extras)
+	}
+}
+
+func Test_TableOptions_CharacterSet(t *testing.T) {
+	s := "CREATE TABLE t (a int) DEFAULT CHARACTER SET latin1 DEFAULT COLLATE latin1_bin;"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table := schema["t"]; table.Charset != "latin1" || table.Collation != "latin1_bin" {
+		t.Errorf("unexpected charset %q or collation %q", table.Charset, table.Collation)
+	}
+}
+
+func Test_TableOptions_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TABLE t (a int) AUTO_INCREMENT=x;", err: `found "x", expected integer AUTO_INCREMENT`},
+		{s: "CREATE TABLE t (a int) ENGINE=;", err: `found ";", expected option value`},
+		{s: "CREATE TABLE t (a int) CHARACTER utf8;", err: `found "CHARACTER utf8", expected CHARACTER SET`},
+		{s: "CREATE TABLE t (a int) = 1;", err: `found "=", expected table option`},
+		{s: "CREATE TABLE t (a int) COMMENT='unterminated;", err: `found "'unterminated;", expected option value`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewParser(strings.NewReader(tt.s)).Parse()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
@@ -13,13 +13,13 @@ func Test_Parser(t *testing.T) {
 		Name:    "id",
 		Type:    "bigint",
 		Size:    20,
-		Default: "null",
+		Default: &NullLit{},
 	}
 	columns["username"] = &Column{
 		Name:    "username",
 		Type:    "varchar",
 		Size:    20,
-		Default: "null",
+		Default: &NullLit{},
 	}
 	extras := make(map[string]string)
 	extras["engine"] = "InnoDB"
@@ -0,0 +1,89 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Transaction_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{s: `BEGIN`, stmt: &SQLParser.BeginStatement{}},
+		{s: `begin work;`, stmt: &SQLParser.BeginStatement{}},
+		{s: `START TRANSACTION`, stmt: &SQLParser.BeginStatement{}},
+		{
+			s:    `START TRANSACTION READ ONLY, WITH CONSISTENT SNAPSHOT;`,
+			stmt: &SQLParser.BeginStatement{AccessMode: "READ ONLY", ConsistentSnapshot: true},
+		},
+		{s: `COMMIT`, stmt: &SQLParser.CommitStatement{}},
+		{s: `COMMIT WORK;`, stmt: &SQLParser.CommitStatement{}},
+		{s: `ROLLBACK`, stmt: &SQLParser.RollbackStatement{}},
+		{s: `ROLLBACK WORK TO SAVEPOINT sp1`, stmt: &SQLParser.RollbackStatement{Savepoint: "sp1"}},
+		{s: `ROLLBACK TO sp1`, stmt: &SQLParser.RollbackStatement{Savepoint: "sp1"}},
+		{s: `SAVEPOINT sp1`, stmt: &SQLParser.SavepointStatement{Name: "sp1"}},
+		{s: `RELEASE SAVEPOINT sp1`, stmt: &SQLParser.ReleaseSavepointStatement{Name: "sp1"}},
+		{
+			s:    `SET TRANSACTION ISOLATION LEVEL READ COMMITTED`,
+			stmt: &SQLParser.SetTransactionStatement{IsolationLevel: "READ COMMITTED"},
+		},
+		{
+			s:    `SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ WRITE`,
+			stmt: &SQLParser.SetTransactionStatement{Scope: "SESSION", IsolationLevel: "REPEATABLE READ", AccessMode: "READ WRITE"},
+		},
+		{
+			s:    `SET GLOBAL TRANSACTION READ ONLY`,
+			stmt: &SQLParser.SetTransactionStatement{Scope: "GLOBAL", AccessMode: "READ ONLY"},
+		},
+		{
+			s: "LOCK TABLES `user` WRITE, `order` AS o READ LOCAL, log LOW_PRIORITY WRITE",
+			stmt: &SQLParser.LockTablesStatement{Tables: []*SQLParser.TableLock{
+				{Table: "user", Mode: "WRITE"},
+				{Table: "order", Alias: "o", Mode: "READ LOCAL"},
+				{Table: "log", Mode: "LOW_PRIORITY WRITE"},
+			}},
+		},
+		{s: `UNLOCK TABLES;`, stmt: &SQLParser.UnlockTablesStatement{}},
+
+		// Errors
+		{s: `START foo`, err: `found "foo", expected TRANSACTION`},
+		{s: `START TRANSACTION READ foo`, err: `found "foo", expected ONLY or WRITE`},
+		{s: `START TRANSACTION WITH SNAPSHOT`, err: `found "SNAPSHOT EOF", expected CONSISTENT SNAPSHOT`},
+		{s: `SAVEPOINT ;`, err: `found ";", expected savepoint name`},
+		{s: `RELEASE sp1`, err: `found "RELEASE sp1", expected RELEASE SAVEPOINT`},
+		{s: `SET TRANSACTION ISOLATION LEVEL READ`, err: `found "EOF", expected COMMITTED or UNCOMMITTED`},
+		{s: `COMMIT foo`, err: `found "foo", expected ;`},
+		{s: `LOCK user WRITE`, err: `found "user", expected TABLES`},
+		{s: `LOCK TABLES user AS u foo`, err: `found "foo", expected READ or WRITE`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
+
+func Test_Transaction_StatementsParser(t *testing.T) {
+	s := "START TRANSACTION;\nUPDATE t SET a='x' WHERE id=1;\nSAVEPOINT sp;\nCOMMIT;"
+	stmts, err := SQLParser.NewParser(strings.NewReader(s)).ParseStatements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 4 {
+		t.Fatalf("expected 4 statements, found %d", len(stmts))
+	}
+	if _, ok := stmts[0].(*SQLParser.BeginStatement); !ok {
+		t.Errorf("expected BeginStatement, found %T", stmts[0])
+	}
+	if _, ok := stmts[3].(*SQLParser.CommitStatement); !ok {
+		t.Errorf("expected CommitStatement, found %T", stmts[3])
+	}
+}
@@ -0,0 +1,80 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Type_Parser(t *testing.T) {
+	var tests = []struct {
+		s      string
+		column *SQLParser.Column
+	}{
+		{s: "price decimal(10,2)", column: &SQLParser.Column{Type: "decimal", Precision: 10, Scale: 2}},
+		{s: "ratio numeric(5)", column: &SQLParser.Column{Type: "decimal", Precision: 5}},
+		{s: "amount dec", column: &SQLParser.Column{Type: "decimal"}},
+		{s: "score float(7,4)", column: &SQLParser.Column{Type: "float", Precision: 7, Scale: 4}},
+		{s: "total double precision", column: &SQLParser.Column{Type: "double"}},
+		{s: "weight real", column: &SQLParser.Column{Type: "double"}},
+		{s: "uuid char(36)", column: &SQLParser.Column{Type: "char", Size: 36}},
+		{s: "code character varying(8)", column: &SQLParser.Column{Type: "varchar", Size: 8}},
+		{s: "hash binary(16)", column: &SQLParser.Column{Type: "binary", Size: 16}},
+		{s: "token varbinary(255)", column: &SQLParser.Column{Type: "varbinary", Size: 255}},
+		{s: "body text", column: &SQLParser.Column{Type: "text"}},
+		{s: "summary tinytext", column: &SQLParser.Column{Type: "tinytext"}},
+		{s: "data blob", column: &SQLParser.Column{Type: "blob"}},
+		{s: "image longblob", column: &SQLParser.Column{Type: "longblob"}},
+		{s: "status enum('new','paid','shipped')", column: &SQLParser.Column{Type: "enum", Members: []string{"new", "paid", "shipped"}}},
+		{s: "flags set('a', 'b')", column: &SQLParser.Column{Type: "set", Members: []string{"a", "b"}}},
+		{s: "meta json", column: &SQLParser.Column{Type: "json"}},
+		{s: "active boolean", column: &SQLParser.Column{Type: "boolean"}},
+		{s: "visible bool", column: &SQLParser.Column{Type: "boolean"}},
+		{s: "stock mediumint(8)", column: &SQLParser.Column{Type: "mediumint", Size: 8}},
+		{s: "count integer", column: &SQLParser.Column{Type: "int"}},
+		{s: "born year", column: &SQLParser.Column{Type: "year"}},
+		{s: "created_at datetime(6)", column: &SQLParser.Column{Type: "datetime", Fsp: 6}},
+		{s: "at time(3)", column: &SQLParser.Column{Type: "time", Fsp: 3}},
+		{s: "location point", column: &SQLParser.Column{Type: "point"}},
+		{s: "area multipolygon", column: &SQLParser.Column{Type: "multipolygon"}},
+		{s: "shape geometry", column: &SQLParser.Column{Type: "geometry"}},
+		{s: "text text", column: &SQLParser.Column{Type: "text"}},
+		{s: "date date", column: &SQLParser.Column{Type: "date"}},
+	}
+
+	for i, tt := range tests {
+		s := "CREATE TABLE t (" + tt.s + ");"
+		schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+		if err != nil {
+			t.Errorf("%d. %q: %v", i, tt.s, err)
+			continue
+		}
+		column := schema["t"].ColumnList[0]
+		tt.column.Name = column.Name
+		tt.column.Nullable = column.Nullable
+		if !reflect.DeepEqual(tt.column, column) {
+			t.Errorf("%d. %q\n\ncolumn mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.column, column)
+		}
+	}
+}
+
+func Test_Type_Errors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: "CREATE TABLE t (a decimal(10,x));", err: `found "decimal(10,x)", expected type(precision, scale)`},
+		{s: "CREATE TABLE t (a enum(1, 2));", err: `found "1", expected 'value'`},
+		{s: "CREATE TABLE t (a enum('x' 'y'));", err: `found "y", expected , or )`},
+		{s: "CREATE TABLE t (a char(x));", err: `found "char(x)", expected type(integer)`},
+		{s: "CREATE TABLE t (a money);", err: `found "money", expected type`},
+	}
+
+	for i, tt := range tests {
+		_, err := SQLParser.NewParser(strings.NewReader(tt.s)).Parse()
+		if errstring(err) != tt.err {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
@@ -20,6 +20,12 @@ func Test_UPDATE_QueryParser(t *testing.T) {
 			stmt: &SQLParser.UpdateStatement{
 				Fields:    []string{"City","Hamburg","CustomerID"},
 				TableName: "Customers",
+				Set:       []*SQLParser.Assignment{{Column: "City", Value: &SQLParser.StringLit{Value: "Hamburg"}}},
+				Where: &SQLParser.BinaryExpr{
+					Op:  SQLParser.EQUAL,
+					LHS: &SQLParser.Ident{Name: "CustomerID"},
+					RHS: &SQLParser.NumberLit{Value: "1"},
+				},
 			},
 		},
 		
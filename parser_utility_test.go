@@ -0,0 +1,141 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_Utility_StatementParser(t *testing.T) {
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{s: `SET NAMES utf8mb4`, stmt: &SQLParser.SetNamesStatement{Charset: "utf8mb4"}},
+		{
+			s:    `SET NAMES 'utf8mb4' COLLATE 'utf8mb4_bin';`,
+			stmt: &SQLParser.SetNamesStatement{Charset: "utf8mb4", Collation: "utf8mb4_bin"},
+		},
+		{s: `SET CHARACTER SET latin1`, stmt: &SQLParser.SetNamesStatement{Charset: "latin1", CharacterSet: true}},
+		{
+			s: `SET @@session.sql_mode = 'ANSI', @saved := @@character_set_client`,
+			stmt: &SQLParser.SetStatement{Assignments: []*SQLParser.VariableAssignment{
+				{
+					Variable: &SQLParser.Variable{Name: "sql_mode", System: true, Scope: "SESSION"},
+					Value:    &SQLParser.StringLit{Value: "ANSI"},
+				},
+				{
+					Variable: &SQLParser.Variable{Name: "saved"},
+					Value:    &SQLParser.Variable{Name: "character_set_client", System: true},
+				},
+			}},
+		},
+		{
+			s: `SET GLOBAL max_connections = 100 * 2, FOREIGN_KEY_CHECKS=0`,
+			stmt: &SQLParser.SetStatement{Assignments: []*SQLParser.VariableAssignment{
+				{
+					Variable: &SQLParser.Variable{Name: "max_connections", System: true, Scope: "GLOBAL"},
+					Value: &SQLParser.BinaryExpr{
+						Op:  SQLParser.ASTERISK,
+						LHS: &SQLParser.NumberLit{Value: "100"},
+						RHS: &SQLParser.NumberLit{Value: "2"},
+					},
+				},
+				{
+					Variable: &SQLParser.Variable{Name: "FOREIGN_KEY_CHECKS", System: true},
+					Value:    &SQLParser.NumberLit{Value: "0"},
+				},
+			}},
+		},
+		{
+			s: `SET @@GLOBAL.GTID_PURGED=/*!80000 '+'*/ 'uuid:1-5'`,
+			stmt: &SQLParser.SetStatement{Assignments: []*SQLParser.VariableAssignment{
+				{
+					Variable: &SQLParser.Variable{Name: "GTID_PURGED", System: true, Scope: "GLOBAL"},
+					Value:    &SQLParser.StringLit{Value: "uuid:1-5"},
+				},
+			}},
+		},
+		{
+			s: `SET @a = 'x' "y" 'z'`,
+			stmt: &SQLParser.SetStatement{Assignments: []*SQLParser.VariableAssignment{
+				{Variable: &SQLParser.Variable{Name: "a"}, Value: &SQLParser.StringLit{Value: "xyz"}},
+			}},
+		},
+		{
+			s:    `SET SESSION TRANSACTION READ ONLY`,
+			stmt: &SQLParser.SetTransactionStatement{Scope: "SESSION", AccessMode: "READ ONLY"},
+		},
+		{s: "USE `shop`", stmt: &SQLParser.UseStatement{Database: "shop"}},
+		{s: `SHOW TABLES`, stmt: &SQLParser.ShowStatement{Kind: "TABLES"}},
+		{
+			s:    `SHOW FULL TABLES FROM shop LIKE 'order%'`,
+			stmt: &SQLParser.ShowStatement{Full: true, Kind: "TABLES", From: "shop", Like: "order%"},
+		},
+		{s: "SHOW CREATE TABLE `user`", stmt: &SQLParser.ShowStatement{Kind: "CREATE TABLE", Name: "user"}},
+		{s: `SHOW COLUMNS FROM user IN shop`, stmt: &SQLParser.ShowStatement{Kind: "COLUMNS", Name: "user", From: "shop"}},
+		{
+			s: `SHOW GLOBAL VARIABLES WHERE Value = 'ON'`,
+			stmt: &SQLParser.ShowStatement{Scope: "GLOBAL", Kind: "VARIABLES", Where: &SQLParser.BinaryExpr{
+				Op:  SQLParser.EQUAL,
+				LHS: &SQLParser.Ident{Name: "Value"},
+				RHS: &SQLParser.StringLit{Value: "ON"},
+			}},
+		},
+		{s: `DESCRIBE user`, stmt: &SQLParser.DescribeStatement{Table: "user"}},
+		{s: `DESC user username;`, stmt: &SQLParser.DescribeStatement{Table: "user", Column: "username"}},
+		{
+			s: `EXPLAIN SELECT * FROM user`,
+			stmt: &SQLParser.ExplainStatement{Statement: &SQLParser.SelectStatement{
+				Fields:    []string{"*"},
+				Exprs:     []SQLParser.Expr{nil},
+				TableName: "user",
+			}},
+		},
+		{
+			s: `EXPLAIN FORMAT=JSON DELETE * FROM user`,
+			stmt: &SQLParser.ExplainStatement{Format: "JSON", Statement: &SQLParser.DeleteStatement{
+				Fields:    []string{"*"},
+				TableName: "user",
+			}},
+		},
+		{
+			s: `EXPLAIN ANALYZE SELECT name FROM user`,
+			stmt: &SQLParser.ExplainStatement{Analyze: true, Statement: &SQLParser.SelectStatement{
+				Fields:    []string{"name"},
+				Exprs:     []SQLParser.Expr{&SQLParser.Ident{Name: "name"}},
+				TableName: "user",
+			}},
+		},
+
+		// Errors
+		{s: `SET NAMES ,`, err: `found ",", expected character set`},
+		{s: `SET x 1`, err: `found "1", expected =`},
+		{s: `USE ;`, err: `found ";", expected database name`},
+		{s: `SHOW LIKE 'x'`, err: `found "LIKE", expected SHOW kind`},
+		{s: `EXPLAIN (`, err: `found "(", expected statement`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
+
+func Test_Utility_SchemaParser(t *testing.T) {
+	s := "SET NAMES utf8mb4;\nSET @saved_cs_client = @@character_set_client;\nUSE `shop`;\n" +
+		"CREATE TABLE `user` (\n  `id` bigint(20) NOT NULL\n);\nSHOW TABLES;"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema["user"] == nil {
+		t.Errorf("expected table user, but not found")
+	}
+}
@@ -0,0 +1,47 @@
+package SQLParser_test
+
+import (
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_VersionedComment_SchemaParser(t *testing.T) {
+	s := "/*!40101 SET NAMES utf8mb4 */;\n" +
+		"/*!50503 CREATE TABLE `skipped` (`id` int) */;\n" +
+		"CREATE TABLE `t` (\n  `id` int NOT NULL /*!80023 INVISIBLE */,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB /*!50100 PARTITION BY HASH (`id`) PARTITIONS 4 */;"
+
+	var tests = []struct {
+		version    int
+		tables     int
+		partitions int
+	}{
+		{version: 0, tables: 1},
+		{version: 50100, tables: 1, partitions: 4},
+		{version: 80036, tables: 2, partitions: 4},
+	}
+
+	for i, tt := range tests {
+		p := SQLParser.NewParser(strings.NewReader(s))
+		p.SetServerVersion(tt.version)
+
+		schema, err := p.Parse()
+		if err != nil {
+			t.Errorf("%d. version %d: %s", i, tt.version, err)
+			continue
+		}
+		if len(schema) != tt.tables {
+			t.Errorf("%d. version %d: expected %d tables, found %v", i, tt.version, tt.tables, schema.TableNames())
+		}
+		partitions := 0
+		if schema["t"].Partitioning != nil {
+			partitions = schema["t"].Partitioning.Count
+		}
+		if partitions != tt.partitions {
+			t.Errorf("%d. version %d: expected %d partitions, found %d", i, tt.version, tt.partitions, partitions)
+		}
+		if invisible := schema["t"].Columns["id"].Invisible; invisible != (tt.version >= 80023) {
+			t.Errorf("%d. version %d: expected id invisible to be %v", i, tt.version, !invisible)
+		}
+	}
+}
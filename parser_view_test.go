@@ -0,0 +1,232 @@
+package SQLParser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"SQLParser"
+)
+
+func Test_View_StatementParser(t *testing.T) {
+	yes, no := true, false
+
+	var tests = []struct {
+		s    string
+		stmt SQLParser.Statement
+		err  string
+	}{
+		{
+			s: "CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `active_user` AS select `user`.`id` AS `id`,`user`.`name` AS `name` from `user` where (`user`.`active` = 1);",
+			stmt: &SQLParser.CreateViewStatement{View: &SQLParser.View{
+				Name:         "active_user",
+				Algorithm:    "UNDEFINED",
+				Definer:      &SQLParser.Grantee{User: "root", Host: "localhost"},
+				Security:     "DEFINER",
+				Definition:   "select `user`.`id` AS `id`,`user`.`name` AS `name` from `user` where (`user`.`active` = 1)",
+				Select: &SQLParser.SelectStatement{
+					Fields:    []string{"user.id", "user.name"},
+					Exprs:     []SQLParser.Expr{&SQLParser.Ident{Table: "user", Name: "id"}, &SQLParser.Ident{Table: "user", Name: "name"}},
+					Aliases:   []string{"id", "name"},
+					TableName: "user",
+					Where:     &SQLParser.ParenExpr{X: &SQLParser.BinaryExpr{Op: SQLParser.EQUAL, LHS: &SQLParser.Ident{Table: "user", Name: "active"}, RHS: &SQLParser.NumberLit{Value: "1"}}},
+				},
+				Dependencies: []string{"user"},
+			}},
+		},
+		{
+			s: `CREATE OR REPLACE VIEW v (a, b) AS SELECT id, name FROM user WHERE id > 0 WITH LOCAL CHECK OPTION`,
+			stmt: &SQLParser.CreateViewStatement{View: &SQLParser.View{
+				Name:        "v",
+				Columns:     []string{"a", "b"},
+				OrReplace:   true,
+				CheckOption: "LOCAL",
+				Definition:  "SELECT id, name FROM user WHERE id > 0",
+				Select: &SQLParser.SelectStatement{
+					Fields:    []string{"id", "name"},
+					Exprs:     []SQLParser.Expr{&SQLParser.Ident{Name: "id"}, &SQLParser.Ident{Name: "name"}},
+					TableName: "user",
+					Where:     &SQLParser.BinaryExpr{Op: SQLParser.GT, LHS: &SQLParser.Ident{Name: "id"}, RHS: &SQLParser.NumberLit{Value: "0"}},
+				},
+				Dependencies: []string{"user"},
+			}},
+		},
+		{
+			s: `CREATE MATERIALIZED VIEW IF NOT EXISTS totals AS SELECT o.id FROM shop.orders o JOIN items i ON i.order_id = o.id, (SELECT 1 FROM tax) x WITH NO DATA`,
+			stmt: &SQLParser.CreateViewStatement{IfNotExists: true, View: &SQLParser.View{
+				Name:         "totals",
+				Materialized: true,
+				WithData:     &no,
+				Definition:   "SELECT o.id FROM shop.orders o JOIN items i ON i.order_id = o.id, (SELECT 1 FROM tax) x",
+				Dependencies: []string{"shop.orders", "items", "tax"},
+			}},
+		},
+		{
+			s: `CREATE MATERIALIZED VIEW m AS SELECT * FROM a, b WITH DATA`,
+			stmt: &SQLParser.CreateViewStatement{View: &SQLParser.View{
+				Name:         "m",
+				Materialized: true,
+				WithData:     &yes,
+				Definition:   "SELECT * FROM a, b",
+				Dependencies: []string{"a", "b"},
+			}},
+		},
+		{s: `DROP VIEW IF EXISTS a, b CASCADE`, stmt: &SQLParser.DropViewStatement{IfExists: true, Views: []string{"a", "b"}}},
+		{s: `DROP MATERIALIZED VIEW m`, stmt: &SQLParser.DropViewStatement{Materialized: true, Views: []string{"m"}}},
+
+		// Errors
+		{s: `CREATE OR VIEW v AS SELECT 1`, err: `found "VIEW", expected OR REPLACE`},
+		{s: `CREATE SQL SECURITY NOBODY VIEW v AS SELECT 1`, err: `found "SECURITY NOBODY", expected SQL SECURITY DEFINER or INVOKER`},
+		{s: `CREATE DEFINER=root TABLE t (id int)`, err: `found "TABLE", expected VIEW`},
+		{s: `CREATE VIEW v SELECT 1`, err: `found "SELECT", expected AS`},
+		{s: `CREATE VIEW v AS`, err: `found "EOF", expected query`},
+		{s: `DROP MATERIALIZED TABLE t`, err: `found "TABLE", expected VIEW`},
+	}
+
+	for i, tt := range tests {
+		stmt, err := SQLParser.NewParser(strings.NewReader(tt.s)).ParseStatement()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		} else if tt.err == "" && !reflect.DeepEqual(tt.stmt, stmt) {
+			t.Errorf("%d. %q\n\nstmt mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", i, tt.s, tt.stmt, stmt)
+		}
+	}
+}
+
+func Test_View_SchemaParser(t *testing.T) {
+	s := "CREATE TABLE user (\n  id int NOT NULL,\n  name varchar(20)\n);\n" +
+		"CREATE VIEW names AS SELECT id, name FROM user;\n" +
+		"CREATE VIEW old AS SELECT * FROM user;\n" +
+		"DROP VIEW old, missing;\n" +
+		"CREATE OR REPLACE VIEW names (user_id) AS SELECT id FROM user;"
+	schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp, got := []string{"names", "user"}, schema.TableNames(); !reflect.DeepEqual(exp, got) {
+		t.Fatalf("tables mismatch:\n  exp=%q\n  got=%q", exp, got)
+	}
+	view := schema["names"]
+	if view.View == nil || view.View.Definition != "SELECT id FROM user" {
+		t.Errorf("expected names to be the replaced view, found %#v", view.View)
+	}
+	if len(view.ColumnList) != 1 || view.Columns["user_id"] == nil {
+		t.Errorf("expected view column user_id, found %v", view.Columns)
+	}
+}
+
+func Test_View_Columns(t *testing.T) {
+	var tests = []struct {
+		s       string
+		columns []string
+	}{
+		{s: "CREATE VIEW v AS SELECT user.id, u.name AS n, id + 1 FROM user", columns: []string{"id", "n", "id + 1"}},
+		{s: "CREATE VIEW v AS SELECT * FROM user", columns: []string{"id", "name"}},
+		{s: "CREATE VIEW v AS SELECT user.*, 1 AS one FROM user", columns: []string{"id", "name", "one"}},
+		{s: "CREATE VIEW v AS SELECT * FROM missing", columns: nil},
+	}
+
+	for i, tt := range tests {
+		s := "CREATE TABLE user (id int, name text);\n" + tt.s
+		schema, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+		if err != nil {
+			t.Errorf("%d. %q: %s", i, tt.s, err)
+			continue
+		}
+		var columns []string
+		for _, column := range schema["v"].ColumnList {
+			columns = append(columns, column.Name)
+		}
+		if !reflect.DeepEqual(tt.columns, columns) {
+			t.Errorf("%d. %q: columns mismatch:\n  exp=%q\n  got=%q", i, tt.s, tt.columns, columns)
+		}
+	}
+}
+
+// the view section of mysqldump 8.0 for a view v on table t
+const viewDump = "CREATE TABLE `t` (\n  `a` int NOT NULL,\n  `b` varchar(10) DEFAULT NULL,\n  PRIMARY KEY (`a`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;\n" +
+	"\n" +
+	"--\n" +
+	"-- Temporary view structure for view `v`\n" +
+	"--\n" +
+	"\n" +
+	"DROP TABLE IF EXISTS `v`;\n" +
+	"/*!50001 DROP VIEW IF EXISTS `v`*/;\n" +
+	"SET @saved_cs_client     = @@character_set_client;\n" +
+	"/*!50503 SET character_set_client = utf8mb4 */;\n" +
+	"/*!50001 CREATE VIEW `v` AS SELECT \n" +
+	" 1 AS `a`,\n" +
+	" 1 AS `b`*/;\n" +
+	"SET character_set_client = @saved_cs_client;\n" +
+	"\n" +
+	"--\n" +
+	"-- Final view structure for view `v`\n" +
+	"--\n" +
+	"\n" +
+	"/*!50001 DROP VIEW IF EXISTS `v`*/;\n" +
+	"/*!50001 SET @saved_cs_client          = @@character_set_client */;\n" +
+	"/*!50001 SET character_set_client      = utf8mb4 */;\n" +
+	"/*!50001 CREATE ALGORITHM=UNDEFINED */\n" +
+	"/*!50013 DEFINER=`root`@`localhost` SQL SECURITY DEFINER */\n" +
+	"/*!50001 VIEW `v` AS select `t`.`a` AS `a`,`t`.`b` AS `b` from `t` where (`t`.`a` > 1) */;\n" +
+	"/*!50001 SET character_set_client      = @saved_cs_client */;\n"
+
+func Test_View_Dump(t *testing.T) {
+	var views []*SQLParser.View
+	p := SQLParser.NewParser(strings.NewReader(viewDump))
+	p.SetServerVersion(80036)
+	for {
+		stmt, err := p.ParseStatement()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stmt == nil {
+			break
+		}
+		if create, ok := stmt.(*SQLParser.CreateViewStatement); ok {
+			views = append(views, create.View)
+		}
+	}
+	if len(views) != 2 {
+		t.Fatalf("expected the temporary and the final view, found %d", len(views))
+	}
+	if exp, got := "select `t`.`a` AS `a`,`t`.`b` AS `b` from `t` where (`t`.`a` > 1)", views[1].Definition; got != exp {
+		t.Errorf("definition mismatch:\n  exp=%q\n  got=%q", exp, got)
+	}
+
+	schema, err := SQLParser.NewDumpReader(strings.NewReader(viewDump)).Read(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	view := schema["v"]
+	if view == nil || view.View == nil || view.View.Algorithm != "UNDEFINED" || view.View.Security != "DEFINER" {
+		t.Fatalf("expected the final view v, found %#v", view)
+	}
+	var columns []string
+	for _, column := range view.ColumnList {
+		columns = append(columns, column.Name)
+	}
+	if exp := []string{"a", "b"}; !reflect.DeepEqual(exp, columns) {
+		t.Errorf("columns mismatch:\n  exp=%q\n  got=%q", exp, columns)
+	}
+}
+
+func Test_View_SchemaErrors(t *testing.T) {
+	var tests = []struct {
+		s   string
+		err string
+	}{
+		{s: `CREATE VIEW v AS SELECT id FROM a`, err: `cannot create view "v", it already exists`},
+		{s: `CREATE VIEW a AS SELECT id FROM a`, err: `cannot create view "a", a table with that name exists`},
+		{s: `DROP VIEW a`, err: `cannot drop view "a", it is a table`},
+		{s: `CREATE OR REPLACE VIEW v AS SELECT id FROM a`},
+	}
+
+	for i, tt := range tests {
+		s := "CREATE TABLE a (id int);\nCREATE VIEW v AS SELECT * FROM a;\n" + tt.s
+		_, err := SQLParser.NewParser(strings.NewReader(s)).Parse()
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("%d. %q: error mismatch:\n  exp=%s\n  got=%s\n\n", i, tt.s, tt.err, err)
+		}
+	}
+}
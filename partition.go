@@ -0,0 +1,342 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/* PARTITION BY clause of CREATE TABLE */
+
+// Partitioning describes how a table or, in Sub, each of its partitions is
+// partitioned.
+type Partitioning struct {
+	Method     string // "RANGE", "LIST", "HASH" or "KEY"
+	Linear     bool
+	Algorithm  int      // ALGORITHM of KEY partitioning
+	Expr       Expr     // partitioning expression of RANGE, LIST and HASH
+	Columns    []string // RANGE COLUMNS, LIST COLUMNS and KEY columns
+	Count      int      // PARTITIONS n or SUBPARTITIONS n
+	Sub        *Partitioning
+	Partitions []*Partition
+}
+
+// Partition is one partition or subpartition definition.
+type Partition struct {
+	Name          string
+	LessThan      []Expr // VALUES LESS THAN (...); empty for MAXVALUE
+	MaxValue      bool   // VALUES LESS THAN MAXVALUE
+	In            []Expr // VALUES IN (...), with a TupleExpr per value of LIST COLUMNS
+	Options       map[string]string
+	Subpartitions []*Partition
+}
+
+// copy returns a deep copy of the partitioning, sharing only the expressions.
+func (part *Partitioning) copy() *Partitioning {
+	if part == nil {
+		return nil
+	}
+	c := *part
+	c.Columns = append([]string(nil), part.Columns...)
+	c.Sub = part.Sub.copy()
+	c.Partitions = copyPartitions(part.Partitions)
+	return &c
+}
+
+func copyPartitions(partitions []*Partition) []*Partition {
+	var list []*Partition
+	for _, partition := range partitions {
+		c := *partition
+		c.LessThan = append([]Expr(nil), partition.LessThan...)
+		c.In = append([]Expr(nil), partition.In...)
+		if partition.Options != nil {
+			c.Options = make(map[string]string)
+			for k, v := range partition.Options {
+				c.Options[k] = v
+			}
+		}
+		c.Subpartitions = copyPartitions(partition.Subpartitions)
+		list = append(list, &c)
+	}
+	return list
+}
+
+// scanPartitioning parses BY ... once PARTITION has been consumed.
+func (p *Parser) scanPartitioning() (*Partitioning, error) {
+	part, err := p.scanPartitionMethod(false)
+	if err != nil {
+		return nil, err
+	}
+	if part.Count, err = p.scanPartitionCount("PARTITIONS"); err != nil {
+		return nil, err
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); isWord(tok, litr, "SUBPARTITION") {
+		if part.Sub, err = p.scanPartitionMethod(true); err != nil {
+			return nil, err
+		}
+		if part.Sub.Count, err = p.scanPartitionCount("SUBPARTITIONS"); err != nil {
+			return nil, err
+		}
+	} else {
+		p.unScan()
+	}
+
+	if p.scanOptional(OPEN_PARENTH) {
+		if part.Partitions, err = p.scanPartitionDefinitions("PARTITION"); err != nil {
+			return nil, err
+		}
+	}
+
+	return part, nil
+}
+
+// scanPartitionMethod parses BY [LINEAR] HASH (expr), BY [LINEAR] KEY
+// [ALGORITHM=n] (columns) and, unless sub is set, BY RANGE or LIST with an
+// expression or COLUMNS (columns).
+func (p *Parser) scanPartitionMethod(sub bool) (*Partitioning, error) {
+	part := &Partitioning{}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != BY {
+		return nil, fmt.Errorf("found %q, expected BY", litr)
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if isWord(tok, litr, "LINEAR") {
+		part.Linear = true
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+
+	switch {
+	case isWord(tok, litr, "HASH"):
+		part.Method = "HASH"
+	case tok == KEY:
+		part.Method = "KEY"
+	case !part.Linear && !sub && (isWord(tok, litr, "RANGE") || isWord(tok, litr, "LIST")):
+		part.Method = strings.ToUpper(litr)
+	default:
+		return nil, fmt.Errorf("found %q, expected RANGE, LIST, HASH or KEY", litr)
+	}
+
+	if part.Method == "KEY" {
+		if tok, litr := p.scanIgnoreWhiteSpace(); isWord(tok, litr, "ALGORITHM") {
+			p.scanOptional(EQUAL)
+			tok, litr := p.scanIgnoreWhiteSpace()
+			if tok != SIZE {
+				return nil, fmt.Errorf("found %q, expected integer ALGORITHM", litr)
+			}
+			part.Algorithm, _ = strconv.Atoi(litr)
+		} else {
+			p.unScan()
+		}
+	}
+
+	columns := part.Method == "KEY"
+	if part.Method == "RANGE" || part.Method == "LIST" {
+		if tok, litr := p.scanIgnoreWhiteSpace(); isWord(tok, litr, "COLUMNS") {
+			columns = true
+		} else {
+			p.unScan()
+		}
+	}
+
+	if !columns {
+		expr, err := p.scanParenthExpr()
+		if err != nil {
+			return nil, err
+		}
+		part.Expr = expr
+		return part, nil
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH {
+		return nil, fmt.Errorf("found %q, expected (", litr)
+	}
+	// KEY () partitions by the primary key
+	if p.scanOptional(CLOSE_PARENTH) {
+		return part, nil
+	}
+	for {
+		tok, litr := p.scanIdent()
+		if tok != IDENT {
+			return nil, fmt.Errorf("found %q, expected ident", litr)
+		}
+		part.Columns = append(part.Columns, litr)
+
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+		case COMMA:
+			continue
+		case CLOSE_PARENTH:
+			return part, nil
+		default:
+			return nil, fmt.Errorf("found %q, expected , or )", litr)
+		}
+	}
+}
+
+// scanPartitionCount parses an optional PARTITIONS n or SUBPARTITIONS n.
+func (p *Parser) scanPartitionCount(word string) (int, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, word) {
+		p.unScan()
+		return 0, nil
+	}
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok != SIZE {
+		return 0, fmt.Errorf("found %q, expected integer %s", litr, word)
+	}
+	n, _ := strconv.Atoi(litr)
+	return n, nil
+}
+
+// scanPartitionDefinitions parses the PARTITION or SUBPARTITION definitions
+// following an opening parenthesis, up to and including the closing one.
+func (p *Parser) scanPartitionDefinitions(word string) ([]*Partition, error) {
+	var partitions []*Partition
+
+	for {
+		partition, err := p.scanPartitionDefinition(word)
+		if err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, partition)
+
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+		case COMMA:
+			continue
+		case CLOSE_PARENTH:
+			return partitions, nil
+		default:
+			return nil, fmt.Errorf("found %q, expected , or )", litr)
+		}
+	}
+}
+
+// scanPartitionDefinition parses PARTITION name [VALUES ...] [options]
+// [(subpartitions)], or SUBPARTITION name [options].
+func (p *Parser) scanPartitionDefinition(word string) (*Partition, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, word) {
+		return nil, fmt.Errorf("found %q, expected %s", litr, word)
+	}
+
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected %s name", litr, strings.ToLower(word))
+	}
+	partition := &Partition{Name: litr, Options: make(map[string]string)}
+
+	if word == "PARTITION" && p.scanOptional(VALUES) {
+		if err := p.scanPartitionValues(partition); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.scanPartitionOptions(partition); err != nil {
+		return nil, err
+	}
+
+	if word == "PARTITION" && p.scanOptional(OPEN_PARENTH) {
+		subpartitions, err := p.scanPartitionDefinitions("SUBPARTITION")
+		if err != nil {
+			return nil, err
+		}
+		partition.Subpartitions = subpartitions
+	}
+
+	return partition, nil
+}
+
+// scanPartitionValues parses LESS THAN {(values) | MAXVALUE} or IN (values)
+// once VALUES has been consumed.
+func (p *Parser) scanPartitionValues(partition *Partition) error {
+	tok, litr := p.scanIgnoreWhiteSpace()
+
+	if tok == IN {
+		values, err := p.scanPartitionValueList()
+		partition.In = values
+		return err
+	}
+
+	tok1, litr1 := p.scanIgnoreWhiteSpace()
+	if !isWord(tok, litr, "LESS") || !isWord(tok1, litr1, "THAN") {
+		return fmt.Errorf("found %q, expected LESS THAN or IN", litr+" "+litr1)
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); isWord(tok, litr, "MAXVALUE") {
+		partition.MaxValue = true
+		return nil
+	}
+	p.unScan()
+
+	values, err := p.scanPartitionValueList()
+	partition.LessThan = values
+	return err
+}
+
+// scanPartitionValueList parses a parenthesized list of values, where each
+// value may itself be a parenthesized tuple.
+func (p *Parser) scanPartitionValueList() ([]Expr, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH {
+		return nil, fmt.Errorf("found %q, expected (", litr)
+	}
+
+	var values []Expr
+	for {
+		var value Expr
+		var err error
+		if p.scanOptional(OPEN_PARENTH) {
+			var list []Expr
+			list, err = p.parseExprList()
+			value = &TupleExpr{Exprs: list}
+		} else {
+			value, err = p.ParseExpr()
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+		case COMMA:
+			continue
+		case CLOSE_PARENTH:
+			return values, nil
+		default:
+			return nil, fmt.Errorf("found %q, expected , or )", litr)
+		}
+	}
+}
+
+// scanPartitionOptions parses [STORAGE] ENGINE, COMMENT, DATA DIRECTORY,
+// INDEX DIRECTORY, MAX_ROWS, MIN_ROWS, TABLESPACE and other name [=] value
+// options into the partition's Options, keyed by lower-cased name.
+func (p *Parser) scanPartitionOptions(partition *Partition) error {
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if tok == COMMA || tok == OPEN_PARENTH || tok == CLOSE_PARENTH || tok == EOF || tok == SEMI_COLON || tok == EQUAL ||
+			(tok != IDENT && !isKeyword(tok)) || isWord(tok, litr, "PARTITION") || isWord(tok, litr, "SUBPARTITION") {
+			p.unScan()
+			return nil
+		}
+
+		name := strings.ToLower(litr)
+		switch {
+		case isWord(tok, litr, "STORAGE"):
+			if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "ENGINE") {
+				return fmt.Errorf("found %q, expected STORAGE ENGINE", litr)
+			}
+			name = "engine"
+		case tok == INDEX || isWord(tok, litr, "DATA"):
+			if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "DIRECTORY") {
+				return fmt.Errorf("found %q, expected DIRECTORY", litr)
+			}
+			name += " directory"
+		}
+
+		p.scanOptional(EQUAL)
+		value, err := p.scanTableOptionValue()
+		if err != nil {
+			return err
+		}
+		partition.Options[name] = value
+	}
+}
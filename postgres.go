@@ -0,0 +1,541 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/* PostgreSQL types, CREATE SEQUENCE, CREATE TYPE and COMMENT ON statements */
+
+// postgresTypes maps the PostgreSQL names of types to the Type of a Column
+// where they differ from MySQL; most of them are not keywords of the scanner.
+var postgresTypes = map[string]string{
+	"real":        "float",
+	"serial":      "int",
+	"serial4":     "int",
+	"bigserial":   "bigint",
+	"serial8":     "bigint",
+	"smallserial": "smallint",
+	"serial2":     "smallint",
+	"int2":        "smallint",
+	"int4":        "int",
+	"int8":        "bigint",
+	"float4":      "float",
+	"float8":      "double",
+	"bpchar":      "char",
+	"timestamptz": "timestamp",
+	"timetz":      "time",
+}
+
+// unknownObjectError reports a statement on a kind of object the parser
+// does not read, such as CREATE EXTENSION. Parse skips these statements in
+// the PostgreSQL and SQLite dialects, whose dumps write many of them.
+type unknownObjectError struct {
+	msg string
+}
+
+func (e *unknownObjectError) Error() string { return e.msg }
+
+// scanPostgresType scans a type named by an identifier: an alias such as
+// int4 or timestamptz, a serial type, an enum declared by CREATE TYPE or any
+// other type, which is kept as written. name has been read.
+func (p *Parser) scanPostgresType(name string, column *Column) error {
+	for p.scanOptional(DOT) {
+		tok, litr := p.scanIdent()
+		if tok != IDENT {
+			return fmt.Errorf("found %q, expected type", litr)
+		}
+		name += "." + litr
+	}
+
+	key := strings.ToLower(strings.TrimPrefix(name, "pg_catalog."))
+	if labels, ok := p.types[name]; ok {
+		column.Type, column.TypeName, column.Members = "enum", name, labels
+		return nil
+	} else if typ, ok := p.sc.dialect.Type(key); ok {
+		column.Type = typ
+		column.AutoIncr = strings.Contains(key, "serial")
+		column.TimeZone = strings.HasSuffix(key, "tz")
+	} else {
+		column.Type = name
+	}
+
+	if !p.scanOptional(OPEN_PARENTH) {
+		return nil
+	}
+	var args []int
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if tok != SIZE {
+			return fmt.Errorf("found %q, expected type(integer)", litr)
+		}
+		n, _ := strconv.Atoi(litr)
+		args = append(args, n)
+
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok == CLOSE_PARENTH {
+			break
+		} else if tok != COMMA || len(args) == 2 {
+			return fmt.Errorf("found %q, expected , or )", litr)
+		}
+	}
+
+	switch {
+	case len(args) == 2:
+		column.Precision, column.Scale = args[0], args[1]
+	case column.Type == "time" || column.Type == "timestamp":
+		column.Fsp = args[0]
+	default:
+		column.Size = args[0]
+	}
+	return nil
+}
+
+// scanTypeSuffix scans WITH TIME ZONE, WITHOUT TIME ZONE and the array
+// dimensions [] or [n], or ARRAY [n], following a type.
+func (p *Parser) scanTypeSuffix(column *Column) error {
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok == WITH || isWord(tok, litr, "WITHOUT") {
+		tok1, litr1 := p.scanIgnoreWhiteSpace()
+		tok2, litr2 := p.scanIgnoreWhiteSpace()
+		if tok1 != TIME || !isWord(tok2, litr2, "ZONE") {
+			return fmt.Errorf("found %q, expected %s TIME ZONE", litr1+" "+litr2, strings.ToUpper(litr))
+		}
+		column.TimeZone = tok == WITH
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+
+	if isWord(tok, litr, "ARRAY") {
+		if tok, _ = p.scanIgnoreWhiteSpace(); tok != OPEN_BRACKET {
+			column.Dimensions++
+			p.unScan()
+			return nil
+		}
+	}
+	for tok == OPEN_BRACKET {
+		tok, litr = p.scanIgnoreWhiteSpace()
+		if tok == SIZE {
+			tok, litr = p.scanIgnoreWhiteSpace()
+		}
+		if tok != CLOSE_BRACKET {
+			return fmt.Errorf("found %q, expected ]", litr)
+		}
+		column.Dimensions++
+		tok, _ = p.scanIgnoreWhiteSpace()
+	}
+	p.unScan()
+	return nil
+}
+
+// typeString formats the type of a column, e.g. varchar(20) or text[].
+func typeString(column *Column) string {
+	s := column.Type
+	if column.TypeName != "" {
+		s = column.TypeName
+	}
+	switch {
+	case column.Precision > 0 && column.Scale > 0:
+		s += fmt.Sprintf("(%d,%d)", column.Precision, column.Scale)
+	case column.Precision > 0:
+		s += fmt.Sprintf("(%d)", column.Precision)
+	case column.Size > 0:
+		s += fmt.Sprintf("(%d)", column.Size)
+	case column.Fsp > 0:
+		s += fmt.Sprintf("(%d)", column.Fsp)
+	}
+	if column.TimeZone {
+		s += " with time zone"
+	}
+	return s + strings.Repeat("[]", column.Dimensions)
+}
+
+// isNextval reports whether a default value takes the next value of a
+// sequence, as the default of a serial column does.
+func isNextval(x interface{}) bool {
+	call, ok := x.(*Call)
+	return ok && call.Name == "NEXTVAL"
+}
+
+// Sequence is a PostgreSQL sequence. MinValue and MaxValue are nil for the
+// defaults of the sequence's type.
+type Sequence struct {
+	Name      string
+	Type      string // bigint when not given
+	Start     int64
+	Increment int64
+	MinValue  *int64
+	MaxValue  *int64
+	Cache     int64
+	Cycle     bool
+	OwnedBy   string // table.column owning the sequence, empty for none
+}
+
+// CreateSequenceStatement is
+// CREATE [TEMPORARY|UNLOGGED] SEQUENCE [IF NOT EXISTS] name [options].
+// Sequences are not kept in a Schema.
+type CreateSequenceStatement struct {
+	Sequence    *Sequence
+	IfNotExists bool
+}
+
+// CreateTypeStatement is CREATE TYPE name AS ENUM ('label', ...) or the
+// composite CREATE TYPE name AS (attribute type, ...). Enum types are
+// remembered by the parser so that columns of the type read as enums.
+type CreateTypeStatement struct {
+	Name       string
+	Kind       string // "ENUM" or "COMPOSITE"
+	Enum       []string
+	Attributes []*Column
+}
+
+// CommentStatement is COMMENT ON object name IS 'text'. Column is set for
+// COMMENT ON COLUMN, whose Name is the table. The Name of objects other
+// than tables, views and columns is kept as written, e.g. f(integer) for a
+// function. A NULL comment is empty.
+type CommentStatement struct {
+	Object  string // "TABLE", "COLUMN", "MATERIALIZED VIEW", ..., upper-cased
+	Name    string
+	Column  string
+	Comment string
+}
+
+func (*CreateSequenceStatement) stmt() {}
+func (*CreateTypeStatement) stmt()     {}
+func (*CommentStatement) stmt()        {}
+
+// parseCreateSequence parses what follows CREATE SEQUENCE.
+func (p *Parser) parseCreateSequence() (*CreateSequenceStatement, error) {
+	stmt := &CreateSequenceStatement{}
+
+	var err error
+	if stmt.IfNotExists, err = p.scanIfNotExists(); err != nil {
+		return nil, err
+	}
+	name, err := p.scanQualifiedName("sequence name")
+	if err != nil {
+		return nil, err
+	}
+	stmt.Sequence = &Sequence{Name: name.String(), Type: "bigint", Start: 1, Increment: 1, Cache: 1}
+	if err := p.scanSequenceOptions(stmt.Sequence); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// scanIdentityOptions scans the optional (sequence options) of an identity
+// column; they are not kept.
+func (p *Parser) scanIdentityOptions() error {
+	if !p.scanOptional(OPEN_PARENTH) {
+		return nil
+	}
+	if err := p.scanSequenceOptions(&Sequence{}); err != nil {
+		return err
+	}
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH {
+		return fmt.Errorf("found %q, expected sequence option or )", litr)
+	}
+	return nil
+}
+
+// scanSequenceOptions scans the options of a sequence up to the first token
+// that does not start one: AS type, INCREMENT [BY] n, [NO] MINVALUE [n],
+// [NO] MAXVALUE [n], START [WITH] n, RESTART [WITH] n, CACHE n, [NO] CYCLE,
+// OWNED BY {table.column|NONE} and the SEQUENCE NAME name of identities.
+func (p *Parser) scanSequenceOptions(seq *Sequence) error {
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		no := isWord(tok, litr, "NO")
+		if no {
+			tok, litr = p.scanIgnoreWhiteSpace()
+		}
+
+		var err error
+		switch {
+		case isWord(tok, litr, "MINVALUE"), isWord(tok, litr, "MAXVALUE"):
+			var value *int64
+			if !no {
+				var n int64
+				if n, err = p.scanSignedInt(); err != nil {
+					return err
+				}
+				value = &n
+			}
+			if isWord(tok, litr, "MINVALUE") {
+				seq.MinValue = value
+			} else {
+				seq.MaxValue = value
+			}
+
+		case isWord(tok, litr, "CYCLE"):
+			seq.Cycle = !no
+
+		case no:
+			return fmt.Errorf("found %q, expected NO MINVALUE, MAXVALUE or CYCLE", litr)
+
+		case isWord(tok, litr, "AS"):
+			column := &Column{}
+			if err = p.scanType(column); err != nil {
+				return err
+			}
+			seq.Type = typeString(column)
+
+		case isWord(tok, litr, "INCREMENT"):
+			p.scanOptional(BY)
+			seq.Increment, err = p.scanSignedInt()
+
+		case tok == START:
+			p.scanOptional(WITH)
+			seq.Start, err = p.scanSignedInt()
+
+		case isWord(tok, litr, "RESTART"):
+			if p.scanOptional(WITH) {
+				seq.Start, err = p.scanSignedInt()
+			} else if tok, _ := p.scanIgnoreWhiteSpace(); tok == SIZE || tok == MINUS {
+				p.unScan()
+				seq.Start, err = p.scanSignedInt()
+			} else {
+				p.unScan()
+			}
+
+		case isWord(tok, litr, "CACHE"):
+			seq.Cache, err = p.scanSignedInt()
+
+		case isWord(tok, litr, "OWNED"):
+			if tok, litr := p.scanIgnoreWhiteSpace(); tok != BY {
+				return fmt.Errorf("found %q, expected OWNED BY", litr)
+			}
+			if tok, litr := p.scanIdent(); isWord(tok, litr, "NONE") {
+				seq.OwnedBy = ""
+				continue
+			}
+			p.unScan()
+			var parts []string
+			for {
+				tok, litr := p.scanIdent()
+				if tok != IDENT {
+					return fmt.Errorf("found %q, expected column name", litr)
+				}
+				parts = append(parts, litr)
+				if !p.scanOptional(DOT) {
+					break
+				}
+			}
+			seq.OwnedBy = strings.Join(parts, ".")
+
+		case isWord(tok, litr, "SEQUENCE"):
+			if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "NAME") {
+				return fmt.Errorf("found %q, expected SEQUENCE NAME", litr)
+			}
+			name, err := p.scanQualifiedName("sequence name")
+			if err != nil {
+				return err
+			}
+			seq.Name = name.String()
+
+		default:
+			p.unScan()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// scanSignedInt scans an integer with an optional sign.
+func (p *Parser) scanSignedInt() (int64, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+	sign := ""
+	if tok == MINUS || tok == PLUS {
+		sign = litr
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+	n, err := strconv.ParseInt(sign+litr, 10, 64)
+	if tok != SIZE || err != nil {
+		return 0, fmt.Errorf("found %q, expected integer", sign+litr)
+	}
+	return n, nil
+}
+
+// parseCreateType parses what follows CREATE TYPE. Other kinds of types,
+// such as ranges, are unknown objects.
+func (p *Parser) parseCreateType() (*CreateTypeStatement, error) {
+	name, err := p.scanQualifiedName("type name")
+	if err != nil {
+		return nil, err
+	}
+	stmt := &CreateTypeStatement{Name: name.String()}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "AS") {
+		return nil, &unknownObjectError{fmt.Sprintf("found %q, expected AS", litr)}
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	switch {
+	case tok == ENUM:
+		stmt.Kind = "ENUM"
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH {
+			return nil, fmt.Errorf("found %q, expected (", litr)
+		}
+		stmt.Enum = []string{}
+		if !p.scanOptional(CLOSE_PARENTH) {
+			for {
+				tok, litr := p.scanIgnoreWhiteSpace()
+				if tok != STRING {
+					return nil, fmt.Errorf("found %q, expected 'label'", litr)
+				}
+				stmt.Enum = append(stmt.Enum, litr)
+
+				if tok, litr := p.scanIgnoreWhiteSpace(); tok == CLOSE_PARENTH {
+					break
+				} else if tok != COMMA {
+					return nil, fmt.Errorf("found %q, expected , or )", litr)
+				}
+			}
+		}
+		if p.types == nil {
+			p.types = make(map[string][]string)
+		}
+		p.types[stmt.Name] = stmt.Enum
+
+	case tok == OPEN_PARENTH:
+		stmt.Kind = "COMPOSITE"
+		for {
+			tok, litr := p.scanIdent()
+			if tok != IDENT {
+				return nil, fmt.Errorf("found %q, expected attribute name", litr)
+			}
+			attribute := &Column{Name: litr}
+			if err := p.scanType(attribute); err != nil {
+				return nil, err
+			}
+			if p.scanOptional(COLLATE) {
+				tok, litr := p.scanIdent()
+				if tok != IDENT {
+					return nil, fmt.Errorf("found %q, expected collation", litr)
+				}
+				attribute.Collation = litr
+			}
+			stmt.Attributes = append(stmt.Attributes, attribute)
+
+			if tok, litr := p.scanIgnoreWhiteSpace(); tok == CLOSE_PARENTH {
+				break
+			} else if tok != COMMA {
+				return nil, fmt.Errorf("found %q, expected , or )", litr)
+			}
+		}
+
+	default:
+		return nil, &unknownObjectError{fmt.Sprintf("found %q, expected ENUM or (", litr)}
+	}
+	return stmt, nil
+}
+
+// parseComment parses COMMENT ON object name IS 'text'.
+func (p *Parser) parseComment() (*CommentStatement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != COMMENT {
+		return nil, fmt.Errorf("found %q, expected COMMENT", litr)
+	}
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != ON {
+		return nil, fmt.Errorf("found %q, expected COMMENT ON", litr)
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok == EOF || tok == SEMI_COLON {
+		return nil, fmt.Errorf("found %q, expected object", litr)
+	}
+	stmt := &CommentStatement{Object: strings.ToUpper(litr)}
+	switch stmt.Object {
+	case "MATERIALIZED", "FOREIGN", "ACCESS", "EVENT", "TEXT", "OPERATOR":
+		// two-word objects such as MATERIALIZED VIEW or TEXT SEARCH ...
+		_, litr := p.scanIgnoreWhiteSpace()
+		stmt.Object += " " + strings.ToUpper(litr)
+	}
+
+	switch stmt.Object {
+	case "TABLE", "VIEW", "MATERIALIZED VIEW", "FOREIGN TABLE":
+		name, err := p.scanTableName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Name = name
+
+	case "COLUMN":
+		var parts []string
+		for {
+			tok, litr := p.scanIdent()
+			if tok != IDENT {
+				return nil, fmt.Errorf("found %q, expected column name", litr)
+			}
+			parts = append(parts, litr)
+			if len(parts) == 4 || !p.scanOptional(DOT) {
+				break
+			}
+		}
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("found %q, expected table.column", parts[0])
+		}
+		n := QualifiedName{Name: parts[len(parts)-2]}
+		if len(parts) > 2 {
+			n.Schema = parts[len(parts)-3]
+		}
+		if len(parts) > 3 {
+			n.Catalog = parts[0]
+		}
+		stmt.Name, stmt.Column = relative(n, p.database), parts[len(parts)-1]
+
+	default:
+		// keep the name of other objects as written, up to IS
+		p.record()
+		for {
+			tok, litr := p.scan()
+			if tok == IS {
+				stmt.Name = strings.TrimSpace(strings.TrimSuffix(p.sc.recorded(), litr))
+				p.unScan()
+				break
+			}
+			if tok == SEMI_COLON || tok == EOF {
+				return nil, fmt.Errorf("found %q, expected IS", litr)
+			}
+		}
+		if stmt.Name == "" {
+			return nil, fmt.Errorf("found %q, expected name", "IS")
+		}
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != IS {
+		return nil, fmt.Errorf("found %q, expected IS", litr)
+	}
+	switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+	case STRING:
+		stmt.Comment = litr
+	case NULL:
+	default:
+		return nil, fmt.Errorf("found %q, expected 'comment' or NULL", litr)
+	}
+	return stmt, nil
+}
+
+// comment sets the comment of a table, view or column; comments on other
+// objects are ignored.
+func (s Schema) comment(stmt *CommentStatement) error {
+	switch stmt.Object {
+	case "TABLE", "VIEW", "MATERIALIZED VIEW", "FOREIGN TABLE":
+		table := s[stmt.Name]
+		if table == nil {
+			return fmt.Errorf("cannot comment on %q, it does not exist", stmt.Name)
+		}
+		return table.setOption("comment", stmt.Comment)
+
+	case "COLUMN":
+		var column *Column
+		if table := s[stmt.Name]; table != nil {
+			column = table.Columns[stmt.Column]
+		}
+		if column == nil {
+			return fmt.Errorf("cannot comment on %q, it does not exist", stmt.Name+"."+stmt.Column)
+		}
+		column.Comment = stmt.Comment
+	}
+	return nil
+}
@@ -0,0 +1,444 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* Account management statements */
+
+// Grantee is an account or role written as user[@host].
+type Grantee struct {
+	User string
+	Host string // empty when no host was given
+}
+
+// Privilege is a privilege such as SELECT, ALL or CREATE VIEW, optionally
+// restricted to some columns.
+type Privilege struct {
+	Name    string // upper-cased; ALL PRIVILEGES is reported as ALL
+	Columns []string
+}
+
+// GrantObject is the privilege level after ON, e.g. TABLE db.*.
+type GrantObject struct {
+	Type     string // "TABLE", "FUNCTION", "PROCEDURE" or empty
+	Database string // "*" for every database, empty for the default one
+	Name     string // "*" for every object of the database
+}
+
+// GrantStatement grants privileges on an object or roles to accounts.
+type GrantStatement struct {
+	Privileges      []*Privilege
+	On              *GrantObject
+	Roles           []string // set instead of Privileges and On for role grants
+	To              []*Grantee
+	WithGrantOption bool
+	WithAdminOption bool
+}
+
+// RevokeStatement revokes privileges on an object or roles from accounts.
+type RevokeStatement struct {
+	Privileges []*Privilege
+	On         *GrantObject // nil for REVOKE ALL, GRANT OPTION and role revokes
+	Roles      []string
+	From       []*Grantee
+}
+
+// UserSpec is an account of CREATE USER with its authentication options.
+type UserSpec struct {
+	Grantee
+	Plugin     string // IDENTIFIED WITH plugin
+	Password   string // IDENTIFIED BY 'password'
+	AuthString string // IDENTIFIED WITH plugin AS 'hash'
+}
+
+// CreateUserStatement is CREATE USER [IF NOT EXISTS] user [auth] [, ...] [options].
+type CreateUserStatement struct {
+	IfNotExists  bool
+	Users        []*UserSpec
+	DefaultRoles []string
+	Options      string // remaining account options, e.g. "PASSWORD EXPIRE"
+}
+
+// DropUserStatement is DROP USER [IF EXISTS] user [, user ...].
+type DropUserStatement struct {
+	IfExists bool
+	Users    []*Grantee
+}
+
+// CreateRoleStatement is CREATE ROLE [IF NOT EXISTS] role [, role ...].
+type CreateRoleStatement struct {
+	IfNotExists bool
+	Roles       []*Grantee
+}
+
+// DropRoleStatement is DROP ROLE [IF EXISTS] role [, role ...].
+type DropRoleStatement struct {
+	IfExists bool
+	Roles    []*Grantee
+}
+
+func (*GrantStatement) stmt()      {}
+func (*RevokeStatement) stmt()     {}
+func (*CreateUserStatement) stmt() {}
+func (*DropUserStatement) stmt()   {}
+func (*CreateRoleStatement) stmt() {}
+func (*DropRoleStatement) stmt()   {}
+
+// scanGrantee parses user[@host], where both parts may be quoted.
+func (p *Parser) scanGrantee() (*Grantee, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok != IDENT && tok != STRING && !p.sc.nonReserved(tok) {
+		return nil, fmt.Errorf("found %q, expected user", litr)
+	}
+	grantee := &Grantee{User: litr}
+
+	// CURRENT_USER may be written as a function call.
+	if isWord(tok, litr, "CURRENT_USER") && p.scanOptional(OPEN_PARENTH) {
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != CLOSE_PARENTH {
+			return nil, fmt.Errorf("found %q, expected )", litr)
+		}
+	}
+
+	// The host is lexed like a user variable: 'bob'@'%' is STRING VARIABLE.
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok == VARIABLE {
+		grantee.Host = strings.TrimPrefix(litr, "@")
+	} else {
+		p.unScan()
+	}
+
+	return grantee, nil
+}
+
+// scanGrantees parses a comma-separated list of accounts or roles.
+func (p *Parser) scanGrantees() ([]*Grantee, error) {
+	var grantees []*Grantee
+	for {
+		grantee, err := p.scanGrantee()
+		if err != nil {
+			return nil, err
+		}
+		grantees = append(grantees, grantee)
+
+		if !p.scanOptional(COMMA) {
+			return grantees, nil
+		}
+	}
+}
+
+// scanPrivileges parses the comma-separated privilege or role list of GRANT
+// and REVOKE, keeping the names as written.
+func (p *Parser) scanPrivileges() ([]*Privilege, error) {
+	var privs []*Privilege
+	for {
+		var words []string
+		tok, litr := p.scanIgnoreWhiteSpace()
+		for tok == IDENT || tok == STRING || (isKeyword(tok) && tok != ON && tok != TO && tok != FROM) {
+			words = append(words, litr)
+			tok, litr = p.scanIgnoreWhiteSpace()
+		}
+		if len(words) == 0 {
+			return nil, fmt.Errorf("found %q, expected privilege", litr)
+		}
+		priv := &Privilege{Name: strings.Join(words, " ")}
+
+		if tok == OPEN_PARENTH {
+			for {
+				tok, litr := p.scanIdent()
+				if tok != IDENT {
+					return nil, fmt.Errorf("found %q, expected column", litr)
+				}
+				priv.Columns = append(priv.Columns, litr)
+
+				if tok, litr := p.scanIgnoreWhiteSpace(); tok == CLOSE_PARENTH {
+					break
+				} else if tok != COMMA {
+					return nil, fmt.Errorf("found %q, expected , or )", litr)
+				}
+			}
+			tok, litr = p.scanIgnoreWhiteSpace()
+		}
+		privs = append(privs, priv)
+
+		if tok != COMMA {
+			p.unScan()
+			return privs, nil
+		}
+	}
+}
+
+// normalizePrivileges upper-cases privilege names and reports ALL PRIVILEGES as ALL.
+func normalizePrivileges(privs []*Privilege) {
+	for _, priv := range privs {
+		priv.Name = strings.ToUpper(priv.Name)
+		if priv.Name == "ALL PRIVILEGES" {
+			priv.Name = "ALL"
+		}
+	}
+}
+
+// privilegeNames returns the names of privs, used when they turn out to be roles.
+func privilegeNames(privs []*Privilege) []string {
+	names := make([]string, len(privs))
+	for i, priv := range privs {
+		names[i] = priv.Name
+	}
+	return names
+}
+
+// scanGrantObject parses [object_type] priv_level after ON.
+func (p *Parser) scanGrantObject() (*GrantObject, error) {
+	obj := &GrantObject{}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok == TABLE || tok == FUNCTION || tok == PROCEDURE {
+		obj.Type = strings.ToUpper(litr)
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+
+	name := func(tok Tokens, litr string) (string, error) {
+		if tok == ASTERISK || tok == IDENT || p.sc.nonReserved(tok) {
+			return litr, nil
+		}
+		return "", fmt.Errorf("found %q, expected privilege level", litr)
+	}
+
+	first, err := name(tok, litr)
+	if err != nil {
+		return nil, err
+	}
+	if !p.scanOptional(DOT) {
+		obj.Name = first
+		return obj, nil
+	}
+
+	obj.Database = first
+	if obj.Name, err = name(p.scanIgnoreWhiteSpace()); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (p *Parser) parseGrant() (*GrantStatement, error) {
+	stmt := &GrantStatement{}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != GRANT {
+		return nil, fmt.Errorf("found %q, expected GRANT", litr)
+	}
+
+	privs, err := p.scanPrivileges()
+	if err != nil {
+		return nil, err
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	switch tok {
+	case ON:
+		normalizePrivileges(privs)
+		stmt.Privileges = privs
+		if stmt.On, err = p.scanGrantObject(); err != nil {
+			return nil, err
+		}
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != TO {
+			return nil, fmt.Errorf("found %q, expected TO", litr)
+		}
+	case TO:
+		stmt.Roles = privilegeNames(privs)
+	default:
+		return nil, fmt.Errorf("found %q, expected ON or TO", litr)
+	}
+
+	if stmt.To, err = p.scanGrantees(); err != nil {
+		return nil, err
+	}
+
+	if p.scanOptional(WITH) {
+		tok1, litr1 := p.scanIgnoreWhiteSpace()
+		tok2, litr2 := p.scanIgnoreWhiteSpace()
+		switch {
+		case tok1 == GRANT && tok2 == OPTION:
+			stmt.WithGrantOption = true
+		case isWord(tok1, litr1, "ADMIN") && tok2 == OPTION:
+			stmt.WithAdminOption = true
+		default:
+			return nil, fmt.Errorf("found %q, expected GRANT OPTION or ADMIN OPTION", litr1+" "+litr2)
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseRevoke() (*RevokeStatement, error) {
+	stmt := &RevokeStatement{}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != REVOKE {
+		return nil, fmt.Errorf("found %q, expected REVOKE", litr)
+	}
+
+	privs, err := p.scanPrivileges()
+	if err != nil {
+		return nil, err
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	switch tok {
+	case ON:
+		normalizePrivileges(privs)
+		stmt.Privileges = privs
+		if stmt.On, err = p.scanGrantObject(); err != nil {
+			return nil, err
+		}
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != FROM {
+			return nil, fmt.Errorf("found %q, expected FROM", litr)
+		}
+	case FROM:
+		// REVOKE ALL [PRIVILEGES], GRANT OPTION FROM ... has no object;
+		// anything else without ON revokes roles.
+		stmt.Roles = privilegeNames(privs)
+		normalizePrivileges(privs)
+		for _, priv := range privs {
+			if priv.Name == "ALL" || priv.Name == "GRANT OPTION" {
+				stmt.Privileges, stmt.Roles = privs, nil
+				break
+			}
+		}
+	default:
+		return nil, fmt.Errorf("found %q, expected ON or FROM", litr)
+	}
+
+	if stmt.From, err = p.scanGrantees(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// parseCreateUser parses the accounts following CREATE USER.
+func (p *Parser) parseCreateUser() (*CreateUserStatement, error) {
+	stmt := &CreateUserStatement{}
+
+	var err error
+	if stmt.IfNotExists, err = p.scanIfNotExists(); err != nil {
+		return nil, err
+	}
+
+	for {
+		grantee, err := p.scanGrantee()
+		if err != nil {
+			return nil, err
+		}
+		user := &UserSpec{Grantee: *grantee}
+
+		if p.scanOptional(IDENTIFIED) {
+			tok, litr := p.scanIgnoreWhiteSpace()
+			if tok == WITH {
+				plugin, name := p.scanIgnoreWhiteSpace()
+				if plugin != IDENT && plugin != STRING {
+					return nil, fmt.Errorf("found %q, expected authentication plugin", name)
+				}
+				user.Plugin = name
+				tok, litr = p.scanIgnoreWhiteSpace()
+			}
+
+			switch {
+			case tok == BY:
+				tok, litr := p.scanIgnoreWhiteSpace()
+				if tok != STRING {
+					return nil, fmt.Errorf("found %q, expected 'password'", litr)
+				}
+				user.Password = litr
+			case isWord(tok, litr, "AS") && user.Plugin != "":
+				tok, litr := p.scanIgnoreWhiteSpace()
+				if tok != STRING {
+					return nil, fmt.Errorf("found %q, expected 'auth_string'", litr)
+				}
+				user.AuthString = litr
+			case user.Plugin != "":
+				p.unScan()
+			default:
+				return nil, fmt.Errorf("found %q, expected BY or WITH", litr)
+			}
+		}
+		stmt.Users = append(stmt.Users, user)
+
+		if !p.scanOptional(COMMA) {
+			break
+		}
+	}
+
+	if p.scanOptional(DEFAULT) {
+		if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "ROLE") {
+			return nil, fmt.Errorf("found %q, expected ROLE", litr)
+		}
+		roles, err := p.scanGrantees()
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			stmt.DefaultRoles = append(stmt.DefaultRoles, role.User)
+		}
+	}
+
+	// Keep REQUIRE, WITH, PASSWORD, ACCOUNT and COMMENT options as written.
+	var options []string
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if tok == SEMI_COLON || tok == EOF {
+			p.unScan()
+			break
+		}
+		if tok == STRING {
+			litr = "'" + strings.Replace(litr, "'", "''", -1) + "'"
+		}
+		options = append(options, litr)
+	}
+	stmt.Options = strings.Join(options, " ")
+
+	return stmt, nil
+}
+
+// parseDropUser parses the accounts following DROP USER.
+func (p *Parser) parseDropUser() (*DropUserStatement, error) {
+	stmt := &DropUserStatement{}
+
+	var err error
+	if stmt.IfExists, err = p.scanIfExists(); err != nil {
+		return nil, err
+	}
+	if stmt.Users, err = p.scanGrantees(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// parseCreateRole parses the roles following CREATE ROLE.
+func (p *Parser) parseCreateRole() (*CreateRoleStatement, error) {
+	stmt := &CreateRoleStatement{}
+
+	var err error
+	if stmt.IfNotExists, err = p.scanIfNotExists(); err != nil {
+		return nil, err
+	}
+	if stmt.Roles, err = p.scanGrantees(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// parseDropRole parses the roles following DROP ROLE.
+func (p *Parser) parseDropRole() (*DropRoleStatement, error) {
+	stmt := &DropRoleStatement{}
+
+	var err error
+	if stmt.IfExists, err = p.scanIfExists(); err != nil {
+		return nil, err
+	}
+	if stmt.Roles, err = p.scanGrantees(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
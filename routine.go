@@ -0,0 +1,514 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* Triggers, stored procedures and functions, and DELIMITER */
+
+// Body is the source text of a trigger or routine body. Start and End are
+// the byte offsets of Text in the input.
+type Body struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// Trigger is a trigger on a table.
+type Trigger struct {
+	Name    string
+	Definer *Grantee // nil when not given
+	Timing  string   // BEFORE, AFTER or the INSTEAD OF of SQLite
+	Event   string   // INSERT, UPDATE or DELETE
+	Columns []string // columns of a SQLite UPDATE OF trigger
+	Table   string
+	Order   string // FOLLOWS or PRECEDES, empty when not given
+	Other   string // the trigger named by FOLLOWS or PRECEDES
+	When    Expr   // WHEN condition of a SQLite trigger
+	Body    *Body
+}
+
+// RoutineParam is a parameter of a procedure or function. The name and type
+// are held in a Column.
+type RoutineParam struct {
+	Mode   string // IN, OUT or INOUT for procedures, empty for functions
+	Column *Column
+}
+
+// Routine is a stored procedure or function.
+type Routine struct {
+	Kind          string // PROCEDURE or FUNCTION
+	Name          string
+	Definer       *Grantee // nil when not given
+	Params        []*RoutineParam
+	Returns       *Column // type returned by a function
+	Comment       string
+	Language      string
+	Deterministic bool
+	DataAccess    string // CONTAINS SQL, NO SQL, READS SQL DATA or MODIFIES SQL DATA
+	Security      string // DEFINER or INVOKER
+	Body          *Body
+}
+
+// CreateTriggerStatement is
+// CREATE [DEFINER = user] TRIGGER [IF NOT EXISTS] name {BEFORE|AFTER} {INSERT|UPDATE|DELETE}
+// ON table FOR EACH ROW [{FOLLOWS|PRECEDES} other] body. In SQLite the
+// timing, BEFORE by default, may be INSTEAD OF, UPDATE OF names columns, FOR
+// EACH ROW is optional and WHEN expr may precede the body.
+type CreateTriggerStatement struct {
+	Trigger     *Trigger
+	IfNotExists bool
+}
+
+// CreateRoutineStatement is
+// CREATE [DEFINER = user] {PROCEDURE|FUNCTION} [IF NOT EXISTS] name ([param, ...])
+// [RETURNS type] [characteristic ...] body.
+type CreateRoutineStatement struct {
+	Routine     *Routine
+	IfNotExists bool
+}
+
+// DropTriggerStatement is DROP TRIGGER [IF EXISTS] name.
+type DropTriggerStatement struct {
+	Name     string
+	IfExists bool
+}
+
+// DropRoutineStatement is DROP {PROCEDURE|FUNCTION} [IF EXISTS] name.
+type DropRoutineStatement struct {
+	Kind     string
+	Name     string
+	IfExists bool
+}
+
+// DelimiterStatement is the DELIMITER command of the mysql client, which
+// changes the string ending statements until the next DELIMITER. Unlike
+// other statements it ends at the end of its line.
+type DelimiterStatement struct {
+	Delimiter string
+}
+
+func (*CreateTriggerStatement) stmt() {}
+func (*CreateRoutineStatement) stmt() {}
+func (*DropTriggerStatement) stmt()   {}
+func (*DropRoutineStatement) stmt()   {}
+func (*DelimiterStatement) stmt()     {}
+
+// parseDelimiter parses DELIMITER and switches the scanner to its argument.
+func (p *Parser) parseDelimiter() (*DelimiterStatement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "DELIMITER") {
+		return nil, fmt.Errorf("found %q, expected DELIMITER", litr)
+	}
+
+	delimiter := p.sc.scanDelimiter()
+	if delimiter == "" {
+		return nil, fmt.Errorf("found %q, expected delimiter", "EOF")
+	}
+	return &DelimiterStatement{Delimiter: delimiter}, nil
+}
+
+// parseCreateTrigger parses what follows TRIGGER.
+func (p *Parser) parseCreateTrigger(definer *Grantee) (*CreateTriggerStatement, error) {
+	trigger := &Trigger{Definer: definer}
+	stmt := &CreateTriggerStatement{Trigger: trigger}
+
+	var err error
+	if stmt.IfNotExists, err = p.scanIfNotExists(); err != nil {
+		return nil, err
+	}
+	if trigger.Name, err = p.scanRoutineName(); err != nil {
+		return nil, err
+	}
+
+	sqlite := p.sc.grammar().TriggerSyntax() == SQLiteTriggers
+	tok, litr := p.scanIgnoreWhiteSpace()
+	switch {
+	case isWord(tok, litr, "BEFORE"), isWord(tok, litr, "AFTER"):
+		trigger.Timing = strings.ToUpper(litr)
+	case sqlite && isWord(tok, litr, "INSTEAD"):
+		if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "OF") {
+			return nil, fmt.Errorf("found %q, expected INSTEAD OF", litr)
+		}
+		trigger.Timing = "INSTEAD OF"
+	case sqlite && (tok == INSERT || tok == UPDATE || tok == DELETE):
+		p.unScan()
+		trigger.Timing = "BEFORE"
+	default:
+		return nil, fmt.Errorf("found %q, expected BEFORE or AFTER", litr)
+	}
+
+	if tok, litr = p.scanIgnoreWhiteSpace(); tok != INSERT && tok != UPDATE && tok != DELETE {
+		return nil, fmt.Errorf("found %q, expected INSERT, UPDATE or DELETE", litr)
+	}
+	trigger.Event = strings.ToUpper(litr)
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); sqlite && trigger.Event == "UPDATE" && isWord(tok, litr, "OF") {
+		for {
+			tok, litr := p.scanIdent()
+			if tok != IDENT {
+				return nil, fmt.Errorf("found %q, expected column name", litr)
+			}
+			trigger.Columns = append(trigger.Columns, litr)
+			if !p.scanOptional(COMMA) {
+				break
+			}
+		}
+	} else {
+		p.unScan()
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != ON {
+		return nil, fmt.Errorf("found %q, expected ON", litr)
+	}
+	if trigger.Table, err = p.scanTableName(); err != nil {
+		return nil, err
+	}
+
+	if tok1, litr1 := p.scanIgnoreWhiteSpace(); !sqlite || isWord(tok1, litr1, "FOR") {
+		tok2, litr2 := p.scanIgnoreWhiteSpace()
+		tok3, litr3 := p.scanIgnoreWhiteSpace()
+		if !isWord(tok1, litr1, "FOR") || !isWord(tok2, litr2, "EACH") || !isWord(tok3, litr3, "ROW") {
+			return nil, fmt.Errorf("found %q, expected FOR EACH ROW", litr1+" "+litr2+" "+litr3)
+		}
+	} else {
+		p.unScan()
+	}
+
+	switch tok, litr := p.scanIgnoreWhiteSpace(); {
+	case isWord(tok, litr, "FOLLOWS"), isWord(tok, litr, "PRECEDES"):
+		trigger.Order = strings.ToUpper(litr)
+		if trigger.Other, err = p.scanRoutineName(); err != nil {
+			return nil, err
+		}
+	case sqlite && isWord(tok, litr, "WHEN"):
+		if trigger.When, err = p.ParseExpr(); err != nil {
+			return nil, err
+		}
+	default:
+		p.unScan()
+	}
+
+	if trigger.Body, err = p.scanBody(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// parseCreateRoutine parses what follows PROCEDURE or FUNCTION, given as kind.
+func (p *Parser) parseCreateRoutine(kind string, definer *Grantee) (*CreateRoutineStatement, error) {
+	routine := &Routine{Kind: kind, Definer: definer}
+	stmt := &CreateRoutineStatement{Routine: routine}
+
+	var err error
+	if stmt.IfNotExists, err = p.scanIfNotExists(); err != nil {
+		return nil, err
+	}
+	if routine.Name, err = p.scanRoutineName(); err != nil {
+		return nil, err
+	}
+	if routine.Params, err = p.scanRoutineParams(kind); err != nil {
+		return nil, err
+	}
+
+	if kind == "FUNCTION" {
+		if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "RETURNS") {
+			return nil, fmt.Errorf("found %q, expected RETURNS", litr)
+		}
+		if routine.Returns, err = p.scanRoutineType(""); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.scanCharacteristics(routine); err != nil {
+		return nil, err
+	}
+	if routine.Body, err = p.scanBody(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// scanRoutineName parses the name of a trigger or routine.
+func (p *Parser) scanRoutineName() (string, error) {
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return "", fmt.Errorf("found %q, expected name", litr)
+	}
+	return litr, nil
+}
+
+// scanRoutineParams parses the parenthesized parameter list of a routine.
+func (p *Parser) scanRoutineParams(kind string) ([]*RoutineParam, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != OPEN_PARENTH {
+		return nil, fmt.Errorf("found %q, expected (", litr)
+	}
+	if p.scanOptional(CLOSE_PARENTH) {
+		return nil, nil
+	}
+
+	var params []*RoutineParam
+	for {
+		param := &RoutineParam{}
+		if kind == "PROCEDURE" {
+			if tok, litr := p.scanIgnoreWhiteSpace(); tok == IN || isWord(tok, litr, "OUT") || isWord(tok, litr, "INOUT") {
+				param.Mode = strings.ToUpper(litr)
+			} else {
+				p.unScan()
+			}
+		}
+
+		tok, litr := p.scanIdent()
+		if tok != IDENT {
+			return nil, fmt.Errorf("found %q, expected parameter name", litr)
+		}
+
+		var err error
+		if param.Column, err = p.scanRoutineType(litr); err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+		case COMMA:
+			continue
+		case CLOSE_PARENTH:
+			return params, nil
+		default:
+			return nil, fmt.Errorf("found %q, expected , or )", litr)
+		}
+	}
+}
+
+// scanRoutineType parses a data type with its optional character set and
+// collation into a column named name.
+func (p *Parser) scanRoutineType(name string) (*Column, error) {
+	column := &Column{Name: name}
+	if err := p.scanType(column); err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		switch {
+		case tok == CHARACTER || isWord(tok, litr, "CHARSET"):
+			p.unScan()
+			if err := p.scanColumnCharset(column); err != nil {
+				return nil, err
+			}
+		case tok == COLLATE:
+			tok, litr := p.scanIdent()
+			if tok != IDENT && tok != STRING {
+				return nil, fmt.Errorf("found %q, expected collation", litr)
+			}
+			column.Collation = litr
+		case tok == UNSIGNED:
+			column.Unsigned = true
+		default:
+			p.unScan()
+			return column, nil
+		}
+	}
+}
+
+// scanCharacteristics parses the characteristics of a routine preceding its body.
+func (p *Parser) scanCharacteristics(routine *Routine) error {
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+
+		switch {
+		case tok == COMMENT:
+			tok, litr := p.scanIgnoreWhiteSpace()
+			if tok != STRING {
+				return fmt.Errorf("found %q, expected 'comment'", litr)
+			}
+			routine.Comment = litr
+
+		case isWord(tok, litr, "LANGUAGE"):
+			tok, litr := p.scanIgnoreWhiteSpace()
+			if tok != IDENT {
+				return fmt.Errorf("found %q, expected language", litr)
+			}
+			routine.Language = strings.ToUpper(litr)
+
+		case isWord(tok, litr, "DETERMINISTIC"):
+			routine.Deterministic = true
+
+		case tok == NOT:
+			if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "DETERMINISTIC") {
+				return fmt.Errorf("found %q, expected NOT DETERMINISTIC", litr)
+			}
+			routine.Deterministic = false
+
+		case isWord(tok, litr, "CONTAINS"), isWord(tok, litr, "NO"):
+			if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "SQL") {
+				return fmt.Errorf("found %q, expected SQL", litr)
+			}
+			routine.DataAccess = strings.ToUpper(litr) + " SQL"
+
+		case isWord(tok, litr, "READS"), isWord(tok, litr, "MODIFIES"):
+			tok1, litr1 := p.scanIgnoreWhiteSpace()
+			tok2, litr2 := p.scanIgnoreWhiteSpace()
+			if !isWord(tok1, litr1, "SQL") || !isWord(tok2, litr2, "DATA") {
+				return fmt.Errorf("found %q, expected SQL DATA", litr1+" "+litr2)
+			}
+			routine.DataAccess = strings.ToUpper(litr) + " SQL DATA"
+
+		case isWord(tok, litr, "SQL"):
+			tok1, litr1 := p.scanIgnoreWhiteSpace()
+			tok2, litr2 := p.scanIgnoreWhiteSpace()
+			if !isWord(tok1, litr1, "SECURITY") || (!isWord(tok2, litr2, "DEFINER") && !isWord(tok2, litr2, "INVOKER")) {
+				return fmt.Errorf("found %q, expected SQL SECURITY DEFINER or INVOKER", litr1+" "+litr2)
+			}
+			routine.Security = strings.ToUpper(litr2)
+
+		default:
+			p.unScan()
+			return nil
+		}
+	}
+}
+
+// scanBody reads the body of a trigger or routine as raw text, up to the end
+// of the statement. When DELIMITER has changed the delimiter only that ends
+// the body; otherwise semicolons inside BEGIN ... END and CASE ... END blocks
+// do not.
+func (p *Parser) scanBody() (*Body, error) {
+	start := p.record()
+	end := start
+
+	depth := 0
+	for {
+		tok, litr := p.scan()
+		if tok == EOF || tok == SEMI_COLON && (litr == p.sc.delimiter || p.sc.delimiter == "" && depth <= 0) {
+			p.unScan()
+			break
+		}
+		if tok != WHITESPACE && tok != ANNOTATION {
+			end = p.sc.pos
+		}
+
+		switch {
+		case tok == BEGIN, isWord(tok, litr, "CASE"):
+			depth++
+		case isWord(tok, litr, "END"):
+			// IF, LOOP, WHILE and REPEAT blocks are not counted, END CASE
+			// and END close the others
+			switch tok, litr := p.scanIgnoreWhiteSpace(); {
+			case tok == IF, isWord(tok, litr, "LOOP"), isWord(tok, litr, "WHILE"), isWord(tok, litr, "REPEAT"):
+				end = p.sc.pos
+			case isWord(tok, litr, "CASE"):
+				end = p.sc.pos
+				depth--
+			default:
+				p.unScan()
+				depth--
+			}
+		}
+	}
+
+	raw := p.sc.recorded()
+	text := strings.TrimSpace(raw[:end-start])
+	if text == "" {
+		return nil, fmt.Errorf("found %q, expected routine body", "EOF")
+	}
+	start += strings.Index(raw, text)
+	return &Body{Text: text, Start: start, End: start + len(text)}, nil
+}
+
+// parseDropTrigger parses what follows DROP TRIGGER.
+func (p *Parser) parseDropTrigger() (*DropTriggerStatement, error) {
+	stmt := &DropTriggerStatement{}
+
+	var err error
+	if stmt.IfExists, err = p.scanIfExists(); err != nil {
+		return nil, err
+	}
+	if stmt.Name, err = p.scanRoutineName(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// parseDropRoutine parses what follows DROP PROCEDURE or DROP FUNCTION.
+func (p *Parser) parseDropRoutine(kind string) (*DropRoutineStatement, error) {
+	stmt := &DropRoutineStatement{Kind: kind}
+
+	var err error
+	if stmt.IfExists, err = p.scanIfExists(); err != nil {
+		return nil, err
+	}
+	if stmt.Name, err = p.scanRoutineName(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// createTrigger adds the trigger of a CREATE TRIGGER statement to its table,
+// placing it after or before the trigger named by FOLLOWS or PRECEDES.
+func (s Schema) createTrigger(stmt *CreateTriggerStatement) error {
+	trigger := stmt.Trigger
+	table := s[trigger.Table]
+	if table == nil {
+		return fmt.Errorf("cannot create trigger %q, table %q does not exist", trigger.Name, trigger.Table)
+	}
+	if _, existing := s.trigger(trigger.Name); existing != nil {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("cannot create trigger %q, it already exists", trigger.Name)
+	}
+
+	at := len(table.Triggers)
+	if trigger.Order != "" {
+		at = -1
+		for i, other := range table.Triggers {
+			if other.Name == trigger.Other {
+				at = i
+				if trigger.Order == "FOLLOWS" {
+					at++
+				}
+			}
+		}
+		if at < 0 {
+			return fmt.Errorf("cannot create trigger %q, trigger %q does not exist", trigger.Name, trigger.Other)
+		}
+	}
+
+	table.Triggers = append(table.Triggers, nil)
+	copy(table.Triggers[at+1:], table.Triggers[at:])
+	table.Triggers[at] = trigger
+	return nil
+}
+
+// dropTrigger removes the trigger of a DROP TRIGGER statement from its table.
+func (s Schema) dropTrigger(stmt *DropTriggerStatement) error {
+	table, trigger := s.trigger(stmt.Name)
+	if trigger == nil {
+		if stmt.IfExists {
+			return nil
+		}
+		return fmt.Errorf("cannot drop trigger %q, it does not exist", stmt.Name)
+	}
+
+	var triggers []*Trigger
+	for _, t := range table.Triggers {
+		if t != trigger {
+			triggers = append(triggers, t)
+		}
+	}
+	table.Triggers = triggers
+	return nil
+}
+
+// trigger looks up a trigger by name in every table.
+func (s Schema) trigger(name string) (*Table, *Trigger) {
+	for _, table := range s {
+		for _, trigger := range table.Triggers {
+			if trigger.Name == name {
+				return table, trigger
+			}
+		}
+	}
+	return nil, nil
+}
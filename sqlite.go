@@ -0,0 +1,95 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/* SQLite column types and conflict clauses */
+
+// scanSQLiteType scans the declared type of a SQLite column: any sequence of
+// names, such as UNSIGNED BIG INT, with an optional (size) or (precision,
+// scale), or no type at all. A single name the dialect has a type for is
+// mapped to it, others are kept lower-cased. The column's Affinity follows
+// SQLite's rules for the declared type.
+func (p *Parser) scanSQLiteType(column *Column) error {
+	var words []string
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		_, typ := p.sc.dialect.Type(strings.ToLower(litr))
+		if !(typ && isKeyword(tok)) && tok != CHARACTER &&
+			(tok != IDENT || isWord(tok, litr, "GENERATED") || isWord(tok, litr, "AS")) {
+			p.unScan()
+			break
+		}
+		words = append(words, litr)
+	}
+
+	declared := strings.Join(words, " ")
+	column.Affinity = affinity(declared)
+	column.Type = strings.ToLower(declared)
+	if typ, ok := p.sc.dialect.Type(column.Type); ok && len(words) == 1 {
+		column.Type = typ
+	}
+	if words == nil || !p.scanOptional(OPEN_PARENTH) {
+		return nil
+	}
+
+	var args []int
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if tok != SIZE {
+			return fmt.Errorf("found %q, expected type(integer)", litr)
+		}
+		n, _ := strconv.Atoi(litr)
+		args = append(args, n)
+
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok == CLOSE_PARENTH {
+			break
+		} else if tok != COMMA || len(args) == 2 {
+			return fmt.Errorf("found %q, expected , or )", litr)
+		}
+	}
+	if len(args) == 2 {
+		column.Precision, column.Scale = args[0], args[1]
+	} else {
+		column.Size = args[0]
+	}
+	return nil
+}
+
+// affinity returns the SQLite type affinity of a declared type.
+func affinity(declared string) string {
+	t := strings.ToUpper(declared)
+	switch {
+	case strings.Contains(t, "INT"):
+		return "INTEGER"
+	case strings.Contains(t, "CHAR"), strings.Contains(t, "CLOB"), strings.Contains(t, "TEXT"):
+		return "TEXT"
+	case t == "", strings.Contains(t, "BLOB"):
+		return "BLOB"
+	case strings.Contains(t, "REAL"), strings.Contains(t, "FLOA"), strings.Contains(t, "DOUB"):
+		return "REAL"
+	}
+	return "NUMERIC"
+}
+
+// scanConflictClause scans the ON CONFLICT {ROLLBACK|ABORT|FAIL|IGNORE|REPLACE}
+// SQLite allows after NOT NULL, PRIMARY KEY and UNIQUE. It returns the
+// upper-cased resolution, or an empty one when there is no clause or the
+// dialect is not SQLite.
+func (p *Parser) scanConflictClause() (string, error) {
+	if !p.sc.grammar().ConflictClauses() || !p.scanOptional(ON) {
+		return "", nil
+	}
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "CONFLICT") {
+		return "", fmt.Errorf("found %q, expected ON CONFLICT", litr)
+	}
+	_, litr := p.scanIgnoreWhiteSpace()
+	switch resolution := strings.ToUpper(litr); resolution {
+	case "ROLLBACK", "ABORT", "FAIL", "IGNORE", "REPLACE":
+		return resolution, nil
+	}
+	return "", fmt.Errorf("found %q, expected ROLLBACK, ABORT, FAIL, IGNORE or REPLACE", litr)
+}
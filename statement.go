@@ -0,0 +1,226 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Statement is implemented by every parsed SQL statement.
+type Statement interface {
+	stmt()
+}
+
+func (*SelectStatement) stmt()      {}
+func (*InsertStatement) stmt()      {}
+func (*DeleteStatement) stmt()      {}
+func (*UpdateStatement) stmt()      {}
+func (*CreateTableStatement) stmt() {}
+
+// ParseStatement parses the next statement of the input, including its
+// terminating semicolon. It returns a nil statement once the input is exhausted.
+// A USE statement sets the Database of the statements after it.
+func (p *Parser) ParseStatement() (Statement, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+	for tok == SEMI_COLON {
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+	if tok == EOF {
+		return nil, nil
+	}
+	p.unScan()
+	p.params = 0
+
+	stmt, err := p.parseStatement(tok, litr)
+	if err != nil {
+		return nil, err
+	}
+	if use, ok := stmt.(*UseStatement); ok {
+		p.database = use.Database
+	}
+	// DELIMITER ends at the end of its line
+	if _, ok := stmt.(*DelimiterStatement); ok {
+		return stmt, nil
+	}
+	if err := p.scanStatementEnd(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// scanStatementEnd consumes the semicolon ending a statement; the end of the
+// input also ends a statement.
+func (p *Parser) scanStatementEnd() error {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok == EOF {
+		p.unScan()
+	} else if tok != SEMI_COLON {
+		return fmt.Errorf("found %q, expected ;", litr)
+	}
+	return nil
+}
+
+// ParseStatements parses every statement of the input.
+func (p *Parser) ParseStatements() ([]Statement, error) {
+	var stmts []Statement
+	for {
+		stmt, err := p.ParseStatement()
+		if err != nil {
+			return stmts, err // return already parsed statements and error
+		}
+		if stmt == nil {
+			return stmts, nil
+		}
+		stmts = append(stmts, stmt)
+	}
+}
+
+// parseStatement dispatches on the leading keyword tok, which has not been consumed yet.
+func (p *Parser) parseStatement(tok Tokens, litr string) (Statement, error) {
+	switch tok {
+	case CREATE:
+		return p.parseCreate()
+	case DROP:
+		return p.parseDrop()
+	case GRANT:
+		return notNil(p.parseGrant())
+	case REVOKE:
+		return notNil(p.parseRevoke())
+	case COMMENT:
+		return notNil(p.parseComment())
+	case TRUNCATE:
+		return notNil(p.parseTruncate())
+	case RENAME:
+		return notNil(p.parseRenameTable())
+	case ALTER:
+		return p.parseAlter()
+	case SELECT:
+		return notNil(p.ParseSelectStatements())
+	case INSERT:
+		return notNil(p.ParseInsertStatements())
+	case DELETE:
+		return notNil(p.ParseDeleteStatements())
+	case UPDATE:
+		return notNil(p.ParseUpdateStatements())
+	case BEGIN, START:
+		return notNil(p.parseBegin())
+	case COMMIT:
+		return notNil(p.parseCommit())
+	case ROLLBACK:
+		return notNil(p.parseRollback())
+	case SAVEPOINT:
+		return notNil(p.parseSavepoint())
+	case RELEASE:
+		return notNil(p.parseReleaseSavepoint())
+	case LOCK:
+		return notNil(p.parseLockTables())
+	case UNLOCK:
+		return notNil(p.parseUnlockTables())
+	case SET:
+		return p.parseSet()
+	case USE:
+		return notNil(p.parseUse())
+	case SHOW:
+		return notNil(p.parseShow())
+	case DESCRIBE, DESC, EXPLAIN:
+		return p.parseExplain()
+	}
+
+	if isWord(tok, litr, "DELIMITER") {
+		return notNil(p.parseDelimiter())
+	}
+
+	return nil, fmt.Errorf("found %q, expected statement", litr)
+}
+
+// parseCreate dispatches on the kind of object following CREATE.
+func (p *Parser) parseCreate() (Statement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != CREATE {
+		return nil, fmt.Errorf("found %q, expected CREATE", litr)
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	switch {
+	case tok == TABLE:
+		return notNil(p.parseCreateTable(false))
+	case tok == TEMPORARY:
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if isWord(tok, litr, "SEQUENCE") {
+			return notNil(p.parseCreateSequence())
+		} else if tok != TABLE {
+			return nil, fmt.Errorf("found %q, expected TABLE", litr)
+		}
+		return notNil(p.parseCreateTable(true))
+	case tok == INDEX:
+		return notNil(p.parseCreateIndex("KEY"))
+	case tok == OR, isWord(tok, litr, "ALGORITHM"), isWord(tok, litr, "DEFINER"), isWord(tok, litr, "SQL"),
+		isWord(tok, litr, "MATERIALIZED"), isWord(tok, litr, "VIEW"),
+		isWord(tok, litr, "TRIGGER"), tok == PROCEDURE, tok == FUNCTION:
+		p.unScan()
+		return p.parseCreateView()
+	case tok == UNIQUE || tok == FULLTEXT || tok == SPATIAL:
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != INDEX {
+			return nil, fmt.Errorf("found %q, expected INDEX", litr)
+		}
+		return notNil(p.parseCreateIndex(strings.ToUpper(litr)))
+	case isWord(tok, litr, "DATABASE"), isWord(tok, litr, "SCHEMA"):
+		return notNil(p.parseCreateDatabase())
+	case isWord(tok, litr, "USER"):
+		return notNil(p.parseCreateUser())
+	case isWord(tok, litr, "ROLE"):
+		return notNil(p.parseCreateRole())
+	case isWord(tok, litr, "UNLOGGED"):
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if tok == TABLE {
+			return notNil(p.parseCreateTable(false))
+		} else if !isWord(tok, litr, "SEQUENCE") {
+			return nil, fmt.Errorf("found %q, expected TABLE or SEQUENCE", litr)
+		}
+		return notNil(p.parseCreateSequence())
+	case isWord(tok, litr, "SEQUENCE"):
+		return notNil(p.parseCreateSequence())
+	case isWord(tok, litr, "TYPE"):
+		return notNil(p.parseCreateType())
+	}
+
+	return nil, &unknownObjectError{fmt.Sprintf("found CREATE %q, expected CREATE TABLE", litr)}
+}
+
+// parseDrop dispatches on the kind of object following DROP.
+func (p *Parser) parseDrop() (Statement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != DROP {
+		return nil, fmt.Errorf("found %q, expected DROP", litr)
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	switch {
+	case tok == TABLE || tok == TEMPORARY:
+		p.unScan()
+		return notNil(p.parseDropTable())
+	case tok == INDEX:
+		return notNil(p.parseDropIndex())
+	case isWord(tok, litr, "VIEW"), isWord(tok, litr, "MATERIALIZED"):
+		p.unScan()
+		return notNil(p.parseDropView())
+	case isWord(tok, litr, "TRIGGER"):
+		return notNil(p.parseDropTrigger())
+	case tok == PROCEDURE || tok == FUNCTION:
+		return notNil(p.parseDropRoutine(strings.ToUpper(litr)))
+	case isWord(tok, litr, "DATABASE"), isWord(tok, litr, "SCHEMA"):
+		return notNil(p.parseDropDatabase())
+	case isWord(tok, litr, "USER"):
+		return notNil(p.parseDropUser())
+	case isWord(tok, litr, "ROLE"):
+		return notNil(p.parseDropRole())
+	}
+
+	return nil, fmt.Errorf("found DROP %q, expected DROP TABLE, INDEX, VIEW, TRIGGER, PROCEDURE, FUNCTION, DATABASE, USER or ROLE", litr)
+}
+
+// notNil converts the result of a typed parse function into a Statement,
+// making sure a failed parse yields a nil interface rather than a typed nil.
+func notNil(stmt Statement, err error) (Statement, error) {
+	if err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
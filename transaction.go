@@ -0,0 +1,287 @@
+package SQLParser
+
+import "fmt"
+
+/* Transaction control and table locking statements */
+
+// BeginStatement is BEGIN [WORK] or START TRANSACTION [characteristic, ...].
+type BeginStatement struct {
+	AccessMode         string // "READ ONLY", "READ WRITE" or empty
+	ConsistentSnapshot bool
+}
+
+// CommitStatement is COMMIT [WORK].
+type CommitStatement struct{}
+
+// RollbackStatement is ROLLBACK [WORK] [TO [SAVEPOINT] name].
+type RollbackStatement struct {
+	Savepoint string // empty unless rolling back to a savepoint
+}
+
+// SavepointStatement is SAVEPOINT name.
+type SavepointStatement struct {
+	Name string
+}
+
+// ReleaseSavepointStatement is RELEASE SAVEPOINT name.
+type ReleaseSavepointStatement struct {
+	Name string
+}
+
+// SetTransactionStatement is SET [GLOBAL|SESSION] TRANSACTION characteristic, ...
+type SetTransactionStatement struct {
+	Scope          string // "GLOBAL", "SESSION" or empty
+	IsolationLevel string // e.g. "REPEATABLE READ", empty if not set
+	AccessMode     string // "READ ONLY", "READ WRITE" or empty
+}
+
+// TableLock is one table of LOCK TABLES.
+type TableLock struct {
+	Table string
+	Alias string
+	Mode  string // "READ", "READ LOCAL", "WRITE" or "LOW_PRIORITY WRITE"
+}
+
+// LockTablesStatement is LOCK {TABLE|TABLES} table [[AS] alias] mode [, ...].
+type LockTablesStatement struct {
+	Tables []*TableLock
+}
+
+// UnlockTablesStatement is UNLOCK {TABLE|TABLES}.
+type UnlockTablesStatement struct{}
+
+func (*BeginStatement) stmt()            {}
+func (*CommitStatement) stmt()           {}
+func (*RollbackStatement) stmt()         {}
+func (*SavepointStatement) stmt()        {}
+func (*ReleaseSavepointStatement) stmt() {}
+func (*SetTransactionStatement) stmt()   {}
+func (*LockTablesStatement) stmt()       {}
+func (*UnlockTablesStatement) stmt()     {}
+
+// scanAccessMode parses ONLY or WRITE after READ has been consumed.
+func (p *Parser) scanAccessMode() (string, error) {
+	switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+	case ONLY:
+		return "READ ONLY", nil
+	case WRITE:
+		return "READ WRITE", nil
+	default:
+		return "", fmt.Errorf("found %q, expected ONLY or WRITE", litr)
+	}
+}
+
+// scanIsolationLevel parses the level following ISOLATION LEVEL.
+func (p *Parser) scanIsolationLevel() (string, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+	switch tok {
+	case SERIALIZABLE:
+		return "SERIALIZABLE", nil
+	case REPEATABLE:
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != READ {
+			return "", fmt.Errorf("found %q, expected READ", litr)
+		}
+		return "REPEATABLE READ", nil
+	case READ:
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+		case COMMITTED:
+			return "READ COMMITTED", nil
+		case UNCOMMITTED:
+			return "READ UNCOMMITTED", nil
+		default:
+			return "", fmt.Errorf("found %q, expected COMMITTED or UNCOMMITTED", litr)
+		}
+	}
+	return "", fmt.Errorf("found %q, expected isolation level", litr)
+}
+
+func (p *Parser) parseBegin() (*BeginStatement, error) {
+	stmt := &BeginStatement{}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok == BEGIN {
+		p.scanOptional(WORK)
+		return stmt, nil
+	}
+	if tok != START {
+		return nil, fmt.Errorf("found %q, expected BEGIN or START", litr)
+	}
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != TRANSACTION {
+		return nil, fmt.Errorf("found %q, expected TRANSACTION", litr)
+	}
+
+	for {
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+		case WITH:
+			tok1, litr1 := p.scanIgnoreWhiteSpace()
+			tok2, litr2 := p.scanIgnoreWhiteSpace()
+			if tok1 != CONSISTENT || tok2 != SNAPSHOT {
+				return nil, fmt.Errorf("found %q, expected CONSISTENT SNAPSHOT", litr1+" "+litr2)
+			}
+			stmt.ConsistentSnapshot = true
+		case READ:
+			mode, err := p.scanAccessMode()
+			if err != nil {
+				return nil, err
+			}
+			stmt.AccessMode = mode
+		default:
+			p.unScan()
+			if stmt.AccessMode != "" || stmt.ConsistentSnapshot {
+				return nil, fmt.Errorf("found %q, expected transaction characteristic", litr)
+			}
+			return stmt, nil
+		}
+
+		if !p.scanOptional(COMMA) {
+			return stmt, nil
+		}
+	}
+}
+
+func (p *Parser) parseCommit() (*CommitStatement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != COMMIT {
+		return nil, fmt.Errorf("found %q, expected COMMIT", litr)
+	}
+	p.scanOptional(WORK)
+	return &CommitStatement{}, nil
+}
+
+func (p *Parser) parseRollback() (*RollbackStatement, error) {
+	stmt := &RollbackStatement{}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != ROLLBACK {
+		return nil, fmt.Errorf("found %q, expected ROLLBACK", litr)
+	}
+	p.scanOptional(WORK)
+
+	if !p.scanOptional(TO) {
+		return stmt, nil
+	}
+	p.scanOptional(SAVEPOINT)
+
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected savepoint name", litr)
+	}
+	stmt.Savepoint = litr
+
+	return stmt, nil
+}
+
+func (p *Parser) parseSavepoint() (*SavepointStatement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != SAVEPOINT {
+		return nil, fmt.Errorf("found %q, expected SAVEPOINT", litr)
+	}
+
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected savepoint name", litr)
+	}
+
+	return &SavepointStatement{Name: litr}, nil
+}
+
+func (p *Parser) parseReleaseSavepoint() (*ReleaseSavepointStatement, error) {
+	tok1, litr1 := p.scanIgnoreWhiteSpace()
+	tok2, litr2 := p.scanIgnoreWhiteSpace()
+	if tok1 != RELEASE || tok2 != SAVEPOINT {
+		return nil, fmt.Errorf("found %q, expected RELEASE SAVEPOINT", litr1+" "+litr2)
+	}
+
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected savepoint name", litr)
+	}
+
+	return &ReleaseSavepointStatement{Name: litr}, nil
+}
+
+// parseSetTransaction parses the characteristics following SET [scope] TRANSACTION.
+func (p *Parser) parseSetTransaction(scope string) (*SetTransactionStatement, error) {
+	stmt := &SetTransactionStatement{Scope: scope}
+
+	for {
+		switch tok, litr := p.scanIgnoreWhiteSpace(); tok {
+		case ISOLATION:
+			if tok, litr := p.scanIgnoreWhiteSpace(); tok != LEVEL {
+				return nil, fmt.Errorf("found %q, expected LEVEL", litr)
+			}
+			level, err := p.scanIsolationLevel()
+			if err != nil {
+				return nil, err
+			}
+			stmt.IsolationLevel = level
+		case READ:
+			mode, err := p.scanAccessMode()
+			if err != nil {
+				return nil, err
+			}
+			stmt.AccessMode = mode
+		default:
+			return nil, fmt.Errorf("found %q, expected ISOLATION LEVEL or READ", litr)
+		}
+
+		if !p.scanOptional(COMMA) {
+			return stmt, nil
+		}
+	}
+}
+
+// parseLockTables parses LOCK TABLES.
+func (p *Parser) parseLockTables() (*LockTablesStatement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != LOCK {
+		return nil, fmt.Errorf("found %q, expected LOCK", litr)
+	}
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != TABLE && tok != TABLES {
+		return nil, fmt.Errorf("found %q, expected TABLES", litr)
+	}
+
+	stmt := &LockTablesStatement{}
+	for {
+		name, err := p.scanTableName()
+		if err != nil {
+			return nil, err
+		}
+		lock := &TableLock{Table: name}
+
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if isWord(tok, litr, "AS") {
+			tok, litr = p.scanIgnoreWhiteSpace()
+		}
+		if tok == IDENT && !isWord(tok, litr, "LOW_PRIORITY") {
+			lock.Alias = litr
+			tok, litr = p.scanIgnoreWhiteSpace()
+		}
+
+		switch {
+		case tok == READ:
+			lock.Mode = "READ"
+			if p.scanOptional(LOCAL) {
+				lock.Mode = "READ LOCAL"
+			}
+		case tok == WRITE:
+			lock.Mode = "WRITE"
+		case isWord(tok, litr, "LOW_PRIORITY") && p.scanOptional(WRITE):
+			lock.Mode = "LOW_PRIORITY WRITE"
+		default:
+			return nil, fmt.Errorf("found %q, expected READ or WRITE", litr)
+		}
+		stmt.Tables = append(stmt.Tables, lock)
+
+		if !p.scanOptional(COMMA) {
+			return stmt, nil
+		}
+	}
+}
+
+// parseUnlockTables parses UNLOCK TABLES.
+func (p *Parser) parseUnlockTables() (*UnlockTablesStatement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != UNLOCK {
+		return nil, fmt.Errorf("found %q, expected UNLOCK", litr)
+	}
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != TABLE && tok != TABLES {
+		return nil, fmt.Errorf("found %q, expected TABLES", litr)
+	}
+	return &UnlockTablesStatement{}, nil
+}
@@ -0,0 +1,323 @@
+package SQLParser
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* SET, USE, SHOW, DESCRIBE and EXPLAIN statements */
+
+// SetStatement is SET variable = value [, variable = value ...].
+type SetStatement struct {
+	Assignments []*VariableAssignment
+}
+
+// VariableAssignment assigns Value to a user or system variable.
+type VariableAssignment struct {
+	Variable *Variable
+	Value    Expr
+}
+
+// SetNamesStatement is SET NAMES charset [COLLATE collation] or
+// SET CHARACTER SET charset.
+type SetNamesStatement struct {
+	Charset      string
+	Collation    string
+	CharacterSet bool // SET CHARACTER SET rather than SET NAMES
+}
+
+// UseStatement is USE database.
+type UseStatement struct {
+	Database string
+}
+
+// ShowStatement is any SHOW statement, e.g. SHOW FULL TABLES FROM db LIKE 'a%'.
+type ShowStatement struct {
+	Full  bool
+	Scope string // "GLOBAL", "SESSION" or empty
+	Kind  string // upper-cased, e.g. "TABLES", "CREATE TABLE", "INDEX"
+	Name  string // object of SHOW CREATE ... or SHOW COLUMNS/INDEX FROM ...
+	From  string // database of FROM or IN
+	Like  string
+	Where Expr
+}
+
+// DescribeStatement is DESCRIBE table [column], also spelled DESC or EXPLAIN.
+type DescribeStatement struct {
+	Table  string
+	Column string
+}
+
+// ExplainStatement is EXPLAIN [options] statement, also spelled DESCRIBE or DESC.
+type ExplainStatement struct {
+	Format    string // e.g. "JSON", "TREE" or empty
+	Analyze   bool
+	Statement Statement
+}
+
+func (*SetStatement) stmt()      {}
+func (*SetNamesStatement) stmt() {}
+func (*UseStatement) stmt()      {}
+func (*ShowStatement) stmt()     {}
+func (*DescribeStatement) stmt() {}
+func (*ExplainStatement) stmt()  {}
+
+// isScope reports whether tok is a variable scope keyword.
+func isScope(tok Tokens) bool {
+	return tok == GLOBAL || tok == SESSION || tok == LOCAL || tok == PERSIST || tok == PERSIST_ONLY
+}
+
+func (p *Parser) parseSet() (Statement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != SET {
+		return nil, fmt.Errorf("found %q, expected SET", litr)
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	switch {
+	case tok == NAMES:
+		return notNil(p.parseSetNames(false))
+	case tok == CHARACTER:
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != SET {
+			return nil, fmt.Errorf("found %q, expected SET", litr)
+		}
+		return notNil(p.parseSetNames(true))
+	case tok == IDENT && strings.EqualFold(litr, "CHARSET"):
+		return notNil(p.parseSetNames(true))
+	case tok == TRANSACTION:
+		return notNil(p.parseSetTransaction(""))
+	}
+
+	scope := ""
+	if isScope(tok) {
+		scope = strings.ToUpper(litr)
+		if p.scanOptional(TRANSACTION) {
+			return notNil(p.parseSetTransaction(scope))
+		}
+	} else {
+		p.unScan()
+	}
+
+	stmt := &SetStatement{}
+	for {
+		v, err := p.scanSetVariable(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		if tok, litr := p.scanIgnoreWhiteSpace(); tok != EQUAL && tok != ASSIGN {
+			return nil, fmt.Errorf("found %q, expected =", litr)
+		}
+
+		value, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Assignments = append(stmt.Assignments, &VariableAssignment{Variable: v, Value: value})
+
+		if !p.scanOptional(COMMA) {
+			return stmt, nil
+		}
+		scope = ""
+	}
+}
+
+// scanSetVariable parses the target of a SET assignment. A scope that has
+// already been consumed is passed in.
+func (p *Parser) scanSetVariable(scope string) (*Variable, error) {
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if scope == "" && isScope(tok) {
+		scope = strings.ToUpper(litr)
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+
+	if tok == VARIABLE && scope == "" {
+		return parseVariable(litr), nil
+	}
+	if tok != IDENT && !p.sc.nonReserved(tok) {
+		return nil, fmt.Errorf("found %q, expected variable", litr)
+	}
+	return &Variable{Name: litr, System: true, Scope: scope}, nil
+}
+
+// parseSetNames parses the charset and collation following SET NAMES or SET CHARACTER SET.
+func (p *Parser) parseSetNames(characterSet bool) (*SetNamesStatement, error) {
+	stmt := &SetNamesStatement{CharacterSet: characterSet}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok != IDENT && tok != STRING && tok != DEFAULT {
+		return nil, fmt.Errorf("found %q, expected character set", litr)
+	}
+	stmt.Charset = litr
+
+	if !characterSet && p.scanOptional(COLLATE) {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if tok != IDENT && tok != STRING && tok != DEFAULT {
+			return nil, fmt.Errorf("found %q, expected collation", litr)
+		}
+		stmt.Collation = litr
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseUse() (*UseStatement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != USE {
+		return nil, fmt.Errorf("found %q, expected USE", litr)
+	}
+
+	tok, litr := p.scanIdent()
+	if tok != IDENT {
+		return nil, fmt.Errorf("found %q, expected database name", litr)
+	}
+
+	return &UseStatement{Database: litr}, nil
+}
+
+func (p *Parser) parseShow() (*ShowStatement, error) {
+	stmt := &ShowStatement{}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != SHOW {
+		return nil, fmt.Errorf("found %q, expected SHOW", litr)
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if tok == FULL {
+		stmt.Full = true
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+	if tok == GLOBAL || tok == SESSION {
+		stmt.Scope = strings.ToUpper(litr)
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+
+	// The kind is every word up to the first clause, e.g. "TABLE STATUS".
+	var kind []string
+	for tok == IDENT || (isKeyword(tok) && tok != FROM && tok != IN && tok != LIKE && tok != WHERE) {
+		kind = append(kind, strings.ToUpper(litr))
+		if tok == CREATE && len(kind) == 1 {
+			_, litr = p.scanIgnoreWhiteSpace()
+			kind = append(kind, strings.ToUpper(litr))
+
+			n, err := p.scanQualifiedName("name")
+			if err != nil {
+				return nil, err
+			}
+			stmt.Name = n.String()
+		}
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+	if len(kind) == 0 {
+		return nil, fmt.Errorf("found %q, expected SHOW kind", litr)
+	}
+	stmt.Kind = strings.Join(kind, " ")
+
+	for {
+		switch tok {
+		case FROM, IN:
+			switch stmt.Kind {
+			case "COLUMNS", "FIELDS", "INDEX", "INDEXES", "KEYS":
+				if stmt.Name == "" {
+					n, err := p.scanQualifiedName("name")
+					if err != nil {
+						return nil, err
+					}
+					stmt.Name = n.String()
+					break
+				}
+				fallthrough
+			default:
+				tok, litr := p.scanIdent()
+				if tok != IDENT {
+					return nil, fmt.Errorf("found %q, expected name", litr)
+				}
+				stmt.From = litr
+			}
+
+		case LIKE:
+			tok, litr := p.scanIgnoreWhiteSpace()
+			if tok != STRING {
+				return nil, fmt.Errorf("found %q, expected 'pattern'", litr)
+			}
+			stmt.Like = litr
+
+		case WHERE:
+			where, err := p.ParseExpr()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Where = where
+
+		default:
+			p.unScan()
+			return stmt, nil
+		}
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+}
+
+// parseExplain parses DESCRIBE, DESC or EXPLAIN, which describe a table when
+// followed by a name and explain a statement otherwise.
+func (p *Parser) parseExplain() (Statement, error) {
+	if tok, litr := p.scanIgnoreWhiteSpace(); tok != DESCRIBE && tok != DESC && tok != EXPLAIN {
+		return nil, fmt.Errorf("found %q, expected EXPLAIN", litr)
+	}
+
+	stmt := &ExplainStatement{}
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+		if tok != IDENT && !p.sc.nonReserved(tok) {
+			p.unScan()
+			break
+		}
+
+		switch next, nlitr := p.scanIgnoreWhiteSpace(); next {
+		case EQUAL:
+			_, value := p.scanIgnoreWhiteSpace()
+			if strings.EqualFold(litr, "FORMAT") {
+				stmt.Format = strings.ToUpper(value)
+			}
+
+		case EOF, SEMI_COLON, IDENT, STRING, DOT:
+			describe := &DescribeStatement{Table: litr}
+			if next == DOT {
+				// the rest of a qualified name such as db.t
+				rest, err := p.scanQualifiedName("table name")
+				if err != nil {
+					return nil, err
+				}
+				if rest.Catalog != "" {
+					return nil, fmt.Errorf("found %q, expected table name", rest.String())
+				}
+				n := QualifiedName{Schema: litr, Name: rest.Name}
+				if rest.Schema != "" {
+					n = QualifiedName{Catalog: litr, Schema: rest.Schema, Name: rest.Name}
+				}
+				describe.Table = n.String()
+				next, nlitr = p.scanIgnoreWhiteSpace()
+			}
+			if next == IDENT || next == STRING {
+				describe.Column = nlitr
+			} else {
+				p.unScan()
+			}
+			return describe, nil
+
+		default:
+			// A flag such as ANALYZE or EXTENDED before the statement.
+			p.unScan()
+			if strings.EqualFold(litr, "ANALYZE") {
+				stmt.Analyze = true
+			}
+		}
+	}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	p.unScan()
+	inner, err := p.parseStatement(tok, litr)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Statement = inner
+
+	return stmt, nil
+}
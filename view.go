@@ -0,0 +1,354 @@
+package SQLParser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/* CREATE VIEW and DROP VIEW statements */
+
+// View is the definition of a view. Views are kept in the Schema as tables
+// whose View field is set, so that they resolve like table references.
+//
+// Definition is the source text of the query. Select is its parse when the
+// query is simple enough for ParseSelectStatements and nil otherwise.
+// Dependencies lists the tables and views the query reads from, in order of
+// appearance.
+type View struct {
+	Name         string
+	Columns      []string
+	OrReplace    bool
+	Materialized bool
+	Algorithm    string   // UNDEFINED, MERGE or TEMPTABLE, upper-cased
+	Definer      *Grantee // nil when not given
+	Security     string   // DEFINER or INVOKER, upper-cased
+	CheckOption  string   // CASCADED or LOCAL for WITH [CASCADED|LOCAL] CHECK OPTION
+	WithData     *bool    // WITH [NO] DATA of a materialized view, nil when not given
+	Definition   string
+	Select       *SelectStatement
+	Dependencies []string
+}
+
+// CreateViewStatement is
+// CREATE [OR REPLACE] [ALGORITHM = x] [DEFINER = user] [SQL SECURITY x] [MATERIALIZED] VIEW
+// [IF NOT EXISTS] name [(columns)] AS query [WITH [CASCADED|LOCAL] CHECK OPTION].
+type CreateViewStatement struct {
+	View        *View
+	IfNotExists bool
+}
+
+// DropViewStatement is DROP [MATERIALIZED] VIEW [IF EXISTS] name [, name ...] [RESTRICT|CASCADE].
+type DropViewStatement struct {
+	Materialized bool
+	IfExists     bool
+	Views        []string
+}
+
+func (*CreateViewStatement) stmt() {}
+func (*DropViewStatement) stmt()   {}
+
+var (
+	checkOption = regexp.MustCompile(`(?is)\s+WITH\s+(?:(CASCADED|LOCAL)\s+)?CHECK\s+OPTION$`)
+	withData    = regexp.MustCompile(`(?is)\s+WITH\s+(NO\s+)?DATA$`)
+)
+
+// parseCreateView parses the options and definition of a view following
+// CREATE; OR REPLACE, ALGORITHM, DEFINER and SQL SECURITY are read here.
+// Triggers and routines share the DEFINER option, so they are dispatched
+// from here too.
+func (p *Parser) parseCreateView() (Statement, error) {
+	view := &View{}
+
+	for {
+		tok, litr := p.scanIgnoreWhiteSpace()
+
+		switch {
+		case tok == OR:
+			if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "REPLACE") {
+				return nil, fmt.Errorf("found %q, expected OR REPLACE", litr)
+			}
+			view.OrReplace = true
+
+		case isWord(tok, litr, "ALGORITHM"):
+			p.scanOptional(EQUAL)
+			tok, litr := p.scanIgnoreWhiteSpace()
+			if tok != IDENT {
+				return nil, fmt.Errorf("found %q, expected UNDEFINED, MERGE or TEMPTABLE", litr)
+			}
+			view.Algorithm = strings.ToUpper(litr)
+
+		case isWord(tok, litr, "DEFINER"):
+			p.scanOptional(EQUAL)
+			definer, err := p.scanGrantee()
+			if err != nil {
+				return nil, err
+			}
+			view.Definer = definer
+
+		case isWord(tok, litr, "SQL"):
+			tok1, litr1 := p.scanIgnoreWhiteSpace()
+			tok2, litr2 := p.scanIgnoreWhiteSpace()
+			if !isWord(tok1, litr1, "SECURITY") || (!isWord(tok2, litr2, "DEFINER") && !isWord(tok2, litr2, "INVOKER")) {
+				return nil, fmt.Errorf("found %q, expected SQL SECURITY DEFINER or INVOKER", litr1+" "+litr2)
+			}
+			view.Security = strings.ToUpper(litr2)
+
+		case isWord(tok, litr, "MATERIALIZED"):
+			view.Materialized = true
+
+		case isWord(tok, litr, "VIEW"):
+			return notNil(p.parseView(view))
+
+		case p.sc.grammar().TriggerSyntax() == UnreadTriggers && (isWord(tok, litr, "TRIGGER") || tok == PROCEDURE || tok == FUNCTION):
+			// the bodies of routines and triggers of such dialects are not read
+			return nil, &unknownObjectError{fmt.Sprintf("found %q, expected VIEW", litr)}
+
+		case isWord(tok, litr, "TRIGGER"):
+			return notNil(p.parseCreateTrigger(view.Definer))
+
+		case tok == PROCEDURE || tok == FUNCTION:
+			return notNil(p.parseCreateRoutine(strings.ToUpper(litr), view.Definer))
+
+		default:
+			return nil, fmt.Errorf("found %q, expected VIEW", litr)
+		}
+	}
+}
+
+// parseView parses what follows VIEW into view.
+func (p *Parser) parseView(view *View) (*CreateViewStatement, error) {
+	stmt := &CreateViewStatement{View: view}
+
+	var err error
+	if stmt.IfNotExists, err = p.scanIfNotExists(); err != nil {
+		return nil, err
+	}
+	if view.Name, err = p.scanTableName(); err != nil {
+		return nil, err
+	}
+
+	if p.scanOptional(OPEN_PARENTH) {
+		for {
+			tok, litr := p.scanIdent()
+			if tok != IDENT {
+				return nil, fmt.Errorf("found %q, expected column name", litr)
+			}
+			view.Columns = append(view.Columns, litr)
+
+			if tok, litr := p.scanIgnoreWhiteSpace(); tok == CLOSE_PARENTH {
+				break
+			} else if tok != COMMA {
+				return nil, fmt.Errorf("found %q, expected , or )", litr)
+			}
+		}
+	}
+
+	if tok, litr := p.scanIgnoreWhiteSpace(); !isWord(tok, litr, "AS") {
+		return nil, fmt.Errorf("found %q, expected AS", litr)
+	}
+
+	// keep the query as written, up to the end of the statement
+	p.record()
+	for {
+		tok, _ := p.scan()
+		if tok == SEMI_COLON || tok == EOF {
+			p.unScan()
+			break
+		}
+	}
+	definition := strings.TrimSpace(strings.TrimSuffix(p.sc.recorded(), ";"))
+
+	if m := checkOption.FindStringSubmatch(definition); m != nil {
+		view.CheckOption = "CASCADED"
+		if m[1] != "" {
+			view.CheckOption = strings.ToUpper(m[1])
+		}
+		definition = definition[:len(definition)-len(m[0])]
+	} else if m := withData.FindStringSubmatch(definition); m != nil {
+		data := m[1] == ""
+		view.WithData = &data
+		definition = definition[:len(definition)-len(m[0])]
+	}
+	if definition == "" {
+		return nil, fmt.Errorf("found %q, expected query", "EOF")
+	}
+	view.Definition = definition
+
+	query := NewParser(strings.NewReader(definition), WithDialect(p.sc.dialect))
+	if selectStmt, err := query.ParseSelectStatements(); err == nil && query.scanStatementEnd() == nil {
+		view.Select = selectStmt
+	}
+	view.Dependencies = dependencies(definition, p.sc.dialect)
+
+	return stmt, nil
+}
+
+// dependencies lists the tables named after FROM and JOIN in a query.
+func dependencies(query string, dialect Dialect) []string {
+	var names []string
+	seen := map[string]bool{}
+
+	scan := NewScanner(strings.NewReader(query), WithDialect(dialect))
+	next := func() (Tokens, string) {
+		for {
+			if tok, litr := scan.Scan(); tok != WHITESPACE && tok != ANNOTATION && tok != HINT {
+				return tok, litr
+			}
+		}
+	}
+
+	// from is true inside a FROM clause, where a comma starts another table;
+	// expect is true where a table name may follow.
+	from, expect := false, false
+	tok, litr := next()
+	for tok != EOF {
+		switch {
+		case expect && (tok == IDENT || scan.nonReserved(tok)):
+			name := litr
+			tok, litr = next()
+			for tok == DOT {
+				if tok, litr = next(); tok != IDENT && !scan.nonReserved(tok) {
+					break
+				}
+				name += "." + litr
+				tok, litr = next()
+			}
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+			expect = false
+			continue
+
+		case tok == FROM:
+			from, expect = true, true
+		case isWord(tok, litr, "JOIN"):
+			expect = true
+		case tok == COMMA:
+			expect = from
+		case tok == SELECT, tok == WHERE, tok == ON, tok == USING, tok == CLOSE_PARENTH,
+			isWord(tok, litr, "GROUP"), isWord(tok, litr, "ORDER"), isWord(tok, litr, "HAVING"),
+			isWord(tok, litr, "LIMIT"), isWord(tok, litr, "UNION"):
+			from, expect = false, false
+		default:
+			expect = false
+		}
+		tok, litr = next()
+	}
+
+	return names
+}
+
+// parseDropView parses [MATERIALIZED] VIEW ... after DROP.
+func (p *Parser) parseDropView() (*DropViewStatement, error) {
+	stmt := &DropViewStatement{}
+
+	tok, litr := p.scanIgnoreWhiteSpace()
+	if isWord(tok, litr, "MATERIALIZED") {
+		stmt.Materialized = true
+		tok, litr = p.scanIgnoreWhiteSpace()
+	}
+	if !isWord(tok, litr, "VIEW") {
+		return nil, fmt.Errorf("found %q, expected VIEW", litr)
+	}
+
+	var err error
+	if stmt.IfExists, err = p.scanIfExists(); err != nil {
+		return nil, err
+	}
+
+	for {
+		name, err := p.scanTableName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Views = append(stmt.Views, name)
+
+		if !p.scanOptional(COMMA) {
+			break
+		}
+	}
+
+	if !p.scanOptional(RESTRICT) {
+		p.scanOptional(CASCADE)
+	}
+	return stmt, nil
+}
+
+// createView adds the view of a CREATE VIEW statement to the schema. Its
+// columns are the declared column list, or else the fields of its query
+// when that query could be parsed.
+func (s Schema) createView(stmt *CreateViewStatement, database string) error {
+	view := stmt.View
+	if existing := s[view.Name]; existing != nil {
+		switch {
+		case stmt.IfNotExists:
+			return nil
+		case existing.View == nil:
+			return fmt.Errorf("cannot create view %q, a table with that name exists", view.Name)
+		case !view.OrReplace:
+			return fmt.Errorf("cannot create view %q, it already exists", view.Name)
+		}
+	}
+
+	name := absolute(view.Name, database)
+	table := newTable(name.Name)
+	table.Catalog, table.Database = name.Catalog, name.Schema
+	table.View = view
+
+	columns := view.Columns
+	if columns == nil && view.Select != nil {
+		columns = s.selectColumns(view.Select)
+	}
+	for _, name := range columns {
+		table.addColumn(&Column{Name: name, Nullable: true})
+	}
+
+	s[view.Name] = table
+	return nil
+}
+
+// selectColumns returns the names of the columns a query selects, as MySQL
+// names them: the alias of a field, the last part of a column name or the
+// text of another expression. * and table.* are expanded to the columns of
+// the queried table when it is in the schema.
+func (s Schema) selectColumns(stmt *SelectStatement) []string {
+	source := s[stmt.TableName]
+	var columns []string
+	for i, field := range stmt.Fields {
+		var x Expr
+		if i < len(stmt.Exprs) {
+			x = stmt.Exprs[i]
+		}
+		ident, _ := x.(*Ident)
+		switch {
+		case stmt.Aliases != nil && stmt.Aliases[i] != "":
+			columns = append(columns, stmt.Aliases[i])
+		case field == "*" || ident != nil && ident.Name == "*":
+			if source == nil || ident != nil && ident.Table != ParseQualifiedName(stmt.TableName).Name {
+				continue
+			}
+			for _, column := range source.ColumnList {
+				columns = append(columns, column.Name)
+			}
+		case ident != nil:
+			columns = append(columns, ident.Name)
+		default:
+			columns = append(columns, field)
+		}
+	}
+	return columns
+}
+
+// dropView removes the views of a DROP VIEW statement; views that do not
+// exist are skipped like DROP TABLE does.
+func (s Schema) dropView(stmt *DropViewStatement) error {
+	for _, name := range stmt.Views {
+		if table := s[name]; table != nil && table.View == nil {
+			return fmt.Errorf("cannot drop view %q, it is a table", name)
+		}
+		delete(s, name)
+	}
+	return nil
+}